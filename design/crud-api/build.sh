@@ -1,2 +1,2 @@
 go build ./...
-go build -o crud-service cmd/server/service.go cmd/server/utils.go
+go build -o crud-service ./cmd/server
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// importMapping describes how the columns of a CSV file map onto an entity
+type importMapping struct {
+	IDColumn      string            `json:"id_column"`      // Column holding the entity Id, required
+	NameColumn    string            `json:"name_column"`    // Column holding the entity name, the Id is used when empty
+	CreatedColumn string            `json:"created_column"` // Column holding the RFC 3339 created date, the import time is used when empty
+	Columns       map[string]string `json:"columns"`        // Metadata key for a column, columns not listed keep their header
+}
+
+// loadMapping reads and checks the JSON column mapping at path
+func loadMapping(path string) (*importMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading mapping file: %v", err)
+	}
+	var mapping importMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("error parsing mapping file: %v", err)
+	}
+	if mapping.IDColumn == "" {
+		return nil, fmt.Errorf("mapping must set id_column")
+	}
+	return &mapping, nil
+}
+
+// parseKind parses a kind written as Major or Major/Minor
+func parseKind(value string) (*pb.Kind, error) {
+	major, minor, _ := strings.Cut(value, "/")
+	if strings.TrimSpace(major) == "" {
+		return nil, fmt.Errorf("kind %q has no major kind", value)
+	}
+	return &pb.Kind{Major: strings.TrimSpace(major), Minor: strings.TrimSpace(minor)}, nil
+}
+
+// parseEntities reads every row of the CSV in r into an entity of the given kind. The whole file is validated
+// before anything is returned, so an error lists every invalid row and no entities are returned. Rows repeating an
+// Id seen earlier in the file are skipped and counted. created is used for rows without a created column.
+func parseEntities(r io.Reader, kind *pb.Kind, mapping *importMapping, created string) ([]*pb.Entity, int, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading CSV header: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{mapping.IDColumn, mapping.NameColumn, mapping.CreatedColumn} {
+		if _, ok := columns[required]; required != "" && !ok {
+			return nil, 0, fmt.Errorf("CSV header has no column %q", required)
+		}
+	}
+	for column := range mapping.Columns {
+		if _, ok := columns[column]; !ok {
+			return nil, 0, fmt.Errorf("CSV header has no column %q", column)
+		}
+	}
+
+	var entities []*pb.Entity
+	var errs []error
+	seen := make(map[string]bool)
+	skipped := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %v", line, err))
+			continue
+		}
+
+		entity, err := rowEntity(header, record, kind, mapping, created)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %v", line, err))
+			continue
+		}
+		if seen[entity.Id] {
+			log.Printf("[importer.parseEntities] Skipping line %d: entity %s already appears earlier in the file", line, entity.Id)
+			skipped++
+			continue
+		}
+		seen[entity.Id] = true
+		entities = append(entities, entity)
+	}
+
+	if len(errs) > 0 {
+		return nil, skipped, errors.Join(errs...)
+	}
+	return entities, skipped, nil
+}
+
+// rowEntity builds the entity for one CSV record. Every column other than the Id, name and created columns becomes
+// a string metadata value, keyed by its mapped name or its header. Empty cells are left out.
+func rowEntity(header, record []string, kind *pb.Kind, mapping *importMapping, created string) (*pb.Entity, error) {
+	entity := &pb.Entity{
+		Kind:     &pb.Kind{Major: kind.Major, Minor: kind.Minor},
+		Created:  created,
+		Metadata: make(map[string]*anypb.Any),
+	}
+
+	name := ""
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		value := strings.TrimSpace(record[i])
+		switch column {
+		case mapping.IDColumn:
+			entity.Id = value
+		case mapping.NameColumn:
+			name = value
+		case mapping.CreatedColumn:
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				return nil, fmt.Errorf("invalid created date %q in column %s: %v", value, column, err)
+			}
+			entity.Created = value
+		default:
+			if value == "" {
+				continue
+			}
+			key := column
+			if mapped, ok := mapping.Columns[column]; ok && mapped != "" {
+				key = mapped
+			}
+			packed, err := anypb.New(wrapperspb.String(value))
+			if err != nil {
+				return nil, fmt.Errorf("error packing column %s: %v", column, err)
+			}
+			entity.Metadata[key] = packed
+		}
+	}
+
+	if entity.Id == "" {
+		return nil, fmt.Errorf("empty Id in column %s", mapping.IDColumn)
+	}
+	if name == "" {
+		name = entity.Id
+	}
+	nameValue, err := anypb.New(wrapperspb.String(name))
+	if err != nil {
+		return nil, fmt.Errorf("error packing name: %v", err)
+	}
+	entity.Name = &pb.TimeBasedValue{StartTime: entity.Created, Value: nameValue}
+	return entity, nil
+}
+
+// entityCreator is the part of the CRUD service client used by the importer
+type entityCreator interface {
+	CreateEntity(ctx context.Context, in *pb.Entity, opts ...grpc.CallOption) (*pb.Entity, error)
+}
+
+// importResult counts the rows handled by an import
+type importResult struct {
+	Created int
+	Skipped int
+	Failed  int
+}
+
+// DefaultImportWorkers is the number of CreateEntity requests the importer has in flight at once
+const DefaultImportWorkers = 4
+
+// importEntities creates the entities batchSize at a time, waiting for a batch to finish before starting the next.
+// The service has no batch create RPC, so each entity is sent with its own CreateEntity request and a batch is not
+// atomic. At most workers requests are in flight at once, which keeps an import within the server's rate limit.
+// Entities that fail to be created are logged and counted.
+func importEntities(ctx context.Context, client entityCreator, entities []*pb.Entity, batchSize, workers int) (importResult, error) {
+	var result importResult
+	if batchSize <= 0 {
+		return result, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+	if workers <= 0 {
+		return result, fmt.Errorf("workers must be positive, got %d", workers)
+	}
+
+	for start := 0; start < len(entities); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		batch := entities[start:min(start+batchSize, len(entities))]
+
+		pending := make(chan *pb.Entity, len(batch))
+		for _, entity := range batch {
+			pending <- entity
+		}
+		close(pending)
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for i := 0; i < min(workers, len(batch)); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for entity := range pending {
+					_, err := client.CreateEntity(ctx, entity)
+					mu.Lock()
+					if err != nil {
+						log.Printf("[importer.importEntities] Failed to create entity %s: %v", entity.Id, err)
+						result.Failed++
+					} else {
+						result.Created++
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		log.Printf("[importer.importEntities] Imported %d of %d entities", start+len(batch), len(entities))
+	}
+	return result, nil
+}
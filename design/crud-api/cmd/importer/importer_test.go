@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// stringMetadata unpacks a string metadata value of entity
+func stringMetadata(t *testing.T, entity *pb.Entity, key string) string {
+	value, ok := entity.Metadata[key]
+	if !assert.True(t, ok, "Expected metadata key %s", key) {
+		return ""
+	}
+	unpacked := &wrapperspb.StringValue{}
+	assert.NoError(t, value.UnmarshalTo(unpacked))
+	return unpacked.Value
+}
+
+// TestParseEntities verifies that CSV rows are mapped onto entities and repeated Ids are skipped
+func TestParseEntities(t *testing.T) {
+	csvData := `code,name,Head Office,founded,notes
+D1,Department of Records,Colombo,2020-01-01T00:00:00Z,
+D2,Department of Archives,Kandy,2021-06-01T00:00:00Z,moved in 2022
+D1,Department of Records,Galle,2020-01-01T00:00:00Z,
+`
+	mapping := &importMapping{
+		IDColumn:      "code",
+		NameColumn:    "name",
+		CreatedColumn: "founded",
+		Columns:       map[string]string{"Head Office": "head_office"},
+	}
+	kind := &pb.Kind{Major: "Organisation", Minor: "Department"}
+
+	entities, skipped, err := parseEntities(strings.NewReader(csvData), kind, mapping, "2025-01-01T00:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, skipped, "Expected the repeated Id to be skipped")
+	if !assert.Len(t, entities, 2) {
+		return
+	}
+
+	first := entities[0]
+	assert.Equal(t, "D1", first.Id)
+	assert.Equal(t, "Organisation", first.Kind.Major)
+	assert.Equal(t, "Department", first.Kind.Minor)
+	assert.Equal(t, "2020-01-01T00:00:00Z", first.Created)
+	assert.Equal(t, "2020-01-01T00:00:00Z", first.Name.StartTime)
+	assert.Equal(t, "Colombo", stringMetadata(t, first, "head_office"), "Expected the mapped metadata key")
+	assert.NotContains(t, first.Metadata, "notes", "Expected empty cells to be left out")
+	assert.NotContains(t, first.Metadata, "code", "Expected the Id column not to be copied to metadata")
+
+	assert.Equal(t, "moved in 2022", stringMetadata(t, entities[1], "notes"), "Expected unmapped columns to keep their header")
+}
+
+// TestParseEntitiesDefaults verifies that the Id is used as the name and the import time as the created date
+func TestParseEntitiesDefaults(t *testing.T) {
+	entities, _, err := parseEntities(strings.NewReader("id,city\nP1,Colombo\n"), &pb.Kind{Major: "Place"}, &importMapping{IDColumn: "id"}, "2025-01-01T00:00:00Z")
+	assert.NoError(t, err)
+	if assert.Len(t, entities, 1) {
+		assert.Equal(t, "2025-01-01T00:00:00Z", entities[0].Created)
+		name := &wrapperspb.StringValue{}
+		assert.NoError(t, entities[0].Name.Value.UnmarshalTo(name))
+		assert.Equal(t, "P1", name.Value)
+	}
+}
+
+// TestParseEntitiesValidation verifies that every invalid row is reported and nothing is returned for an invalid file
+func TestParseEntitiesValidation(t *testing.T) {
+	mapping := &importMapping{IDColumn: "code", CreatedColumn: "founded"}
+	csvData := "code,founded\nD1,2020-01-01T00:00:00Z\n,2020-01-01T00:00:00Z\nD3,yesterday\n"
+
+	entities, _, err := parseEntities(strings.NewReader(csvData), &pb.Kind{Major: "Organisation"}, mapping, "")
+	assert.Nil(t, entities, "Expected no entities from an invalid file")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "line 3: empty Id")
+		assert.Contains(t, err.Error(), "line 4: invalid created date")
+	}
+
+	_, _, err = parseEntities(strings.NewReader("id,name\nP1,Colombo\n"), &pb.Kind{Major: "Place"}, &importMapping{IDColumn: "code"}, "")
+	assert.ErrorContains(t, err, `no column "code"`, "Expected a missing Id column to be reported")
+
+	_, _, err = parseEntities(strings.NewReader("id,name\nP1,Colombo\n"), &pb.Kind{Major: "Place"}, &importMapping{IDColumn: "id", Columns: map[string]string{"city": "city"}}, "")
+	assert.ErrorContains(t, err, `no column "city"`, "Expected a missing mapped column to be reported")
+}
+
+// TestParseKind verifies parsing of Major and Major/Minor kinds
+func TestParseKind(t *testing.T) {
+	kind, err := parseKind("Organisation/Department")
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.Kind{Major: "Organisation", Minor: "Department"}, kind)
+
+	kind, err = parseKind("Person")
+	assert.NoError(t, err)
+	assert.Equal(t, "Person", kind.Major)
+	assert.Empty(t, kind.Minor)
+
+	_, err = parseKind("/Department")
+	assert.Error(t, err)
+}
+
+// TestLoadMapping verifies that a mapping file must name the Id column
+func TestLoadMapping(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	assert.NoError(t, os.WriteFile(valid, []byte(`{"id_column": "code", "columns": {"Head Office": "head_office"}}`), 0o600))
+	mapping, err := loadMapping(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, "code", mapping.IDColumn)
+	assert.Equal(t, "head_office", mapping.Columns["Head Office"])
+
+	missingID := filepath.Join(dir, "missing.json")
+	assert.NoError(t, os.WriteFile(missingID, []byte(`{"name_column": "name"}`), 0o600))
+	_, err = loadMapping(missingID)
+	assert.Error(t, err)
+}
+
+// fakeCreator records created entities and fails for the Ids in failIDs. It also records the most requests it
+// served at the same time.
+type fakeCreator struct {
+	mu        sync.Mutex
+	created   []string
+	failIDs   map[string]bool
+	active    int
+	maxActive int
+}
+
+func (f *fakeCreator) CreateEntity(ctx context.Context, in *pb.Entity, opts ...grpc.CallOption) (*pb.Entity, error) {
+	f.mu.Lock()
+	f.active++
+	f.maxActive = max(f.maxActive, f.active)
+	f.mu.Unlock()
+	time.Sleep(time.Millisecond)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.active--
+	if f.failIDs[in.Id] {
+		return nil, errors.New("create failed")
+	}
+	f.created = append(f.created, in.Id)
+	return in, nil
+}
+
+// TestImportEntities verifies that entities are created in batches and failures are counted
+func TestImportEntities(t *testing.T) {
+	var entities []*pb.Entity
+	for _, id := range []string{"E1", "E2", "E3", "E4", "E5"} {
+		entities = append(entities, &pb.Entity{Id: id})
+	}
+	creator := &fakeCreator{failIDs: map[string]bool{"E4": true}}
+
+	result, err := importEntities(context.Background(), creator, entities, 2, DefaultImportWorkers)
+	assert.NoError(t, err)
+	assert.Equal(t, importResult{Created: 4, Failed: 1}, result)
+	assert.ElementsMatch(t, []string{"E1", "E2", "E3", "E5"}, creator.created)
+
+	_, err = importEntities(context.Background(), creator, entities, 0, DefaultImportWorkers)
+	assert.Error(t, err, "Expected a non-positive batch size to be rejected")
+	_, err = importEntities(context.Background(), creator, entities, 2, 0)
+	assert.Error(t, err, "Expected a non-positive worker count to be rejected")
+}
+
+// TestImportEntitiesWorkers verifies that a batch never has more requests in flight than there are workers
+func TestImportEntitiesWorkers(t *testing.T) {
+	var entities []*pb.Entity
+	for i := 0; i < 100; i++ {
+		entities = append(entities, &pb.Entity{Id: fmt.Sprintf("E%d", i)})
+	}
+	creator := &fakeCreator{}
+
+	result, err := importEntities(context.Background(), creator, entities, 100, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, result.Created)
+	assert.LessOrEqual(t, creator.maxActive, 3, "Expected at most 3 requests in flight")
+}
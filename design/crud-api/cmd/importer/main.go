@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+	"lk/datafoundation/crud-api/pkg/client"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// importer creates one entity per row of a CSV file through the CRUD service.
+// The whole file is validated before the first entity is created. Entities are sent in batches of CreateEntity
+// requests, a few at a time, as the service has no batch create RPC.
+//
+//	go run ./cmd/importer --file departments.csv --kind Organisation/Department --mapping mapping.json
+//
+// with a mapping such as {"id_column": "code", "name_column": "name", "columns": {"Head Office": "head_office"}}.
+func main() {
+	file := flag.String("file", "", "path of the CSV file to import")
+	kindFlag := flag.String("kind", "", "kind of the imported entities, as Major or Major/Minor")
+	mappingFile := flag.String("mapping", "", "path of the JSON column mapping")
+	addr := flag.String("addr", "localhost:50051", "address of the CRUD service")
+	batchSize := flag.Int("batch-size", 100, "number of entities created per batch")
+	workers := flag.Int("workers", DefaultImportWorkers, "number of entities created at the same time")
+	flag.Parse()
+
+	if *file == "" || *kindFlag == "" || *mappingFile == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	kind, err := parseKind(*kindFlag)
+	if err != nil {
+		log.Fatalf("[importer.main] %v", err)
+	}
+	mapping, err := loadMapping(*mappingFile)
+	if err != nil {
+		log.Fatalf("[importer.main] %v", err)
+	}
+
+	csvFile, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("[importer.main] Failed to open %s: %v", *file, err)
+	}
+	entities, skipped, err := parseEntities(csvFile, kind, mapping, time.Now().UTC().Format(time.RFC3339))
+	csvFile.Close()
+	if err != nil {
+		log.Fatalf("[importer.main] %s is invalid, nothing was imported:\n%v", *file, err)
+	}
+
+	conn, err := grpc.NewClient(*addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(client.NewRetryInterceptor(3, 200*time.Millisecond, 2*time.Second)),
+	)
+	if err != nil {
+		log.Fatalf("[importer.main] Failed to connect to %s: %v", *addr, err)
+	}
+	defer conn.Close()
+
+	result, err := importEntities(context.Background(), pb.NewCrudServiceClient(conn), entities, *batchSize, *workers)
+	result.Skipped = skipped
+	log.Printf("[importer.main] Import finished: %d created, %d skipped, %d failed", result.Created, result.Skipped, result.Failed)
+	if err != nil {
+		log.Fatalf("[importer.main] Import stopped: %v", err)
+	}
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
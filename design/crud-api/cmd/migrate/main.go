@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"lk/datafoundation/crud-api/db/config"
+	mongorepository "lk/datafoundation/crud-api/db/repository/mongo"
+	neo4jrepository "lk/datafoundation/crud-api/db/repository/neo4j"
+)
+
+// migrate backfills the kind and created fields of MongoDB documents created before the Neo4j integration.
+// It reads the same MONGO_* and NEO4J_* environment variables as the CRUD server.
+//
+//	go run ./cmd/migrate --dry-run --batch-size 100
+func main() {
+	dryRun := flag.Bool("dry-run", false, "log the changes without writing them")
+	batchSize := flag.Int("batch-size", 100, "number of documents read per batch")
+	flag.Parse()
+
+	mongoConfig := &config.MongoConfig{
+		URI:        os.Getenv("MONGO_URI"),
+		DBName:     os.Getenv("MONGO_DB_NAME"),
+		Collection: os.Getenv("MONGO_COLLECTION"),
+	}
+	neo4jConfig := &config.Neo4jConfig{
+		URI:      os.Getenv("NEO4J_URI"),
+		Username: os.Getenv("NEO4J_USER"),
+		Password: os.Getenv("NEO4J_PASSWORD"),
+	}
+
+	ctx := context.Background()
+	mongoRepo := mongorepository.NewMongoRepository(ctx, mongoConfig, nil)
+	neo4jRepo, err := neo4jrepository.NewNeo4jRepository(ctx, neo4jConfig, nil)
+	if err != nil {
+		log.Fatalf("[migrate.main] Failed to create Neo4j repository: %v", err)
+	}
+	defer neo4jRepo.Close(ctx)
+
+	m := &migrator{
+		documents: mongoRepo,
+		graph:     neo4jRepo,
+		batchSize: *batchSize,
+		dryRun:    *dryRun,
+	}
+	// Documents of every tenant are backfilled, so the graph is read without a tenant scope
+	result, err := m.run(neo4jrepository.WithAllTenants(ctx))
+	log.Printf("[migrate.main] Processed %d documents: %d updated, %d failed (dry run: %v)", result.Processed, result.Updated, result.Failed, *dryRun)
+	if err != nil {
+		log.Fatalf("[migrate.main] Migration stopped: %v", err)
+	}
+}
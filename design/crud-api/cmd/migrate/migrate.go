@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+)
+
+// documentStore is the part of the MongoDB repository used by the migration
+type documentStore interface {
+	ListIdsMissingKindOrCreated(ctx context.Context, afterID string, limit int) ([]string, error)
+	SetKindAndCreated(ctx context.Context, id string, kind *pb.Kind, created string) error
+}
+
+// graphStore is the part of the Neo4j repository used by the migration
+type graphStore interface {
+	ReadGraphEntity(ctx context.Context, entityID string) (map[string]interface{}, error)
+}
+
+// migrationResult counts the documents seen by a migration run
+type migrationResult struct {
+	Processed int
+	Updated   int
+	Failed    int
+}
+
+// migrator backfills the kind and created fields of MongoDB documents from the matching Neo4j entities
+type migrator struct {
+	documents documentStore
+	graph     graphStore
+	batchSize int
+	dryRun    bool
+}
+
+// run pages through the documents missing kind or created, batchSize at a time, and copies both fields from Neo4j.
+// Documents whose entity can't be read or updated are counted as failed and skipped. In dry-run mode the changes are
+// only logged, and Updated counts the documents that would have been updated.
+func (m *migrator) run(ctx context.Context) (migrationResult, error) {
+	var result migrationResult
+	if m.batchSize <= 0 {
+		return result, fmt.Errorf("batch size must be positive, got %d", m.batchSize)
+	}
+
+	afterID := ""
+	for {
+		ids, err := m.documents.ListIdsMissingKindOrCreated(ctx, afterID, m.batchSize)
+		if err != nil {
+			return result, fmt.Errorf("error listing documents after %q: %v", afterID, err)
+		}
+
+		for _, id := range ids {
+			result.Processed++
+			if err := m.backfill(ctx, id); err != nil {
+				log.Printf("[migrate.run] Failed to backfill entity %s: %v", id, err)
+				result.Failed++
+				continue
+			}
+			result.Updated++
+		}
+
+		if len(ids) < m.batchSize {
+			return result, nil
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
+
+// backfill copies the kind and created date of one entity from Neo4j to its MongoDB document
+func (m *migrator) backfill(ctx context.Context, id string) error {
+	entity, err := m.graph.ReadGraphEntity(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error reading entity from Neo4j: %v", err)
+	}
+
+	kind := &pb.Kind{Major: stringField(entity, "MajorKind"), Minor: stringField(entity, "MinorKind")}
+	created := stringField(entity, "Created")
+	if kind.Major == "" || created == "" {
+		return fmt.Errorf("entity in Neo4j has no kind or created date")
+	}
+
+	if m.dryRun {
+		log.Printf("[migrate.backfill] Dry run: would set kind %s/%s and created %s on entity %s", kind.Major, kind.Minor, created, id)
+		return nil
+	}
+	if err := m.documents.SetKindAndCreated(ctx, id, kind, created); err != nil {
+		return fmt.Errorf("error updating document: %v", err)
+	}
+	log.Printf("[migrate.backfill] Set kind %s/%s and created %s on entity %s", kind.Major, kind.Minor, created, id)
+	return nil
+}
+
+// stringField reads a string property of an entity map, treating missing and null values as empty
+func stringField(entity map[string]interface{}, key string) string {
+	value, ok := entity[key].(string)
+	if !ok || value == "<nil>" {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDocumentStore keeps documents in memory, recording which have kind and created set
+type fakeDocumentStore struct {
+	missing map[string]bool
+	updated map[string]*pb.Kind
+	pages   int
+}
+
+func newFakeDocumentStore(ids ...string) *fakeDocumentStore {
+	store := &fakeDocumentStore{missing: make(map[string]bool), updated: make(map[string]*pb.Kind)}
+	for _, id := range ids {
+		store.missing[id] = true
+	}
+	return store
+}
+
+func (s *fakeDocumentStore) ListIdsMissingKindOrCreated(ctx context.Context, afterID string, limit int) ([]string, error) {
+	s.pages++
+	var ids []string
+	for id, missing := range s.missing {
+		if missing && id > afterID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (s *fakeDocumentStore) SetKindAndCreated(ctx context.Context, id string, kind *pb.Kind, created string) error {
+	s.missing[id] = false
+	s.updated[id] = kind
+	return nil
+}
+
+// fakeGraphStore serves entities from a map and fails for unknown Ids
+type fakeGraphStore map[string]map[string]interface{}
+
+func (g fakeGraphStore) ReadGraphEntity(ctx context.Context, entityID string) (map[string]interface{}, error) {
+	entity, ok := g[entityID]
+	if !ok {
+		return nil, fmt.Errorf("entity with Id %s not found", entityID)
+	}
+	return entity, nil
+}
+
+// newFakeGraph builds a graph holding a ministry for each Id
+func newFakeGraph(ids ...string) fakeGraphStore {
+	graph := fakeGraphStore{}
+	for _, id := range ids {
+		graph[id] = map[string]interface{}{
+			"Id":        id,
+			"MajorKind": "Organization",
+			"MinorKind": "Ministry",
+			"Created":   "2025-01-01T00:00:00Z",
+		}
+	}
+	return graph
+}
+
+// TestMigratorBackfills verifies that every document is updated across batches and missing entities are counted as failed
+func TestMigratorBackfills(t *testing.T) {
+	documents := newFakeDocumentStore("entity-1", "entity-2", "entity-3", "entity-4", "entity-5")
+	graph := newFakeGraph("entity-1", "entity-2", "entity-4", "entity-5")
+
+	m := &migrator{documents: documents, graph: graph, batchSize: 2}
+	result, err := m.run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, migrationResult{Processed: 5, Updated: 4, Failed: 1}, result, "Expected entity-3 to fail")
+	assert.Equal(t, 3, documents.pages, "Expected the documents to be read in batches of two")
+
+	assert.Equal(t, &pb.Kind{Major: "Organization", Minor: "Ministry"}, documents.updated["entity-1"], "Expected the kind from Neo4j")
+	assert.NotContains(t, documents.updated, "entity-3", "Expected the failed entity not to be updated")
+}
+
+// TestMigratorDryRun verifies that a dry run counts the changes without writing them
+func TestMigratorDryRun(t *testing.T) {
+	documents := newFakeDocumentStore("entity-1", "entity-2")
+	graph := newFakeGraph("entity-1", "entity-2")
+
+	m := &migrator{documents: documents, graph: graph, batchSize: 100, dryRun: true}
+	result, err := m.run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, migrationResult{Processed: 2, Updated: 2}, result)
+	assert.Empty(t, documents.updated, "Expected nothing to be written in a dry run")
+}
+
+// TestMigratorInvalidBatchSize verifies that a non-positive batch size is rejected
+func TestMigratorInvalidBatchSize(t *testing.T) {
+	m := &migrator{documents: newFakeDocumentStore(), graph: fakeGraphStore{}, batchSize: 0}
+	_, err := m.run(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"lk/datafoundation/crud-api/pkg/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthWatchInterval is how often Watch re-checks the backing databases
+const healthWatchInterval = 10 * time.Second
+
+// healthPingTimeout bounds each database ping, so a hung database is reported as NOT_SERVING instead of stalling the check
+const healthPingTimeout = 5 * time.Second
+
+// pinger is implemented by the repositories the health check depends on
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// HealthServer implements grpc_health_v1.HealthServer by checking Neo4j and MongoDB connectivity
+type HealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	neo4j       pinger
+	mongo       pinger
+	interval    time.Duration
+	pingTimeout time.Duration
+	logger      logging.Logger
+}
+
+// NewHealthServer creates a health server backed by the given Neo4j and MongoDB repositories
+func NewHealthServer(neo4j pinger, mongo pinger, logger logging.Logger) *HealthServer {
+	return &HealthServer{
+		neo4j:       neo4j,
+		mongo:       mongo,
+		interval:    healthWatchInterval,
+		pingTimeout: healthPingTimeout,
+		logger:      logger,
+	}
+}
+
+// status reports SERVING only when both databases respond within the ping timeout
+func (h *HealthServer) status(ctx context.Context) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if err := h.ping(ctx, h.neo4j); err != nil {
+		h.logger.Warnf("[server.HealthCheck] Neo4j connectivity check failed: %v", err)
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	if err := h.ping(ctx, h.mongo); err != nil {
+		h.logger.Warnf("[server.HealthCheck] MongoDB connectivity check failed: %v", err)
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+// ping pings one database, giving up after the ping timeout
+func (h *HealthServer) ping(ctx context.Context, db pinger) error {
+	ctx, cancel := context.WithTimeout(ctx, h.pingTimeout)
+	defer cancel()
+	return db.Ping(ctx)
+}
+
+// Check returns the current serving status of the CRUD service
+func (h *HealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return &grpc_health_v1.HealthCheckResponse{Status: h.status(ctx)}, nil
+}
+
+// Watch sends the current serving status, then re-checks on every tick and sends again whenever it changes
+func (h *HealthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc.ServerStreamingServer[grpc_health_v1.HealthCheckResponse]) error {
+	ctx := stream.Context()
+	last := h.status(ctx)
+	if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: last}); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			current := h.status(ctx)
+			if current == last {
+				continue
+			}
+			last = current
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"lk/datafoundation/crud-api/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fakePinger is a pinger whose result is fixed by the test
+type fakePinger struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (p *fakePinger) Ping(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *fakePinger) setErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// hangingPinger is a pinger that never answers, returning only once its context is done
+type hangingPinger struct{}
+
+func (hangingPinger) Ping(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// recordingHealthStream captures the statuses sent by Watch
+type recordingHealthStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan grpc_health_v1.HealthCheckResponse_ServingStatus
+}
+
+func (s *recordingHealthStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recordingHealthStream) Send(resp *grpc_health_v1.HealthCheckResponse) error {
+	s.sent <- resp.Status
+	return nil
+}
+
+// TestHealthCheckNeo4jUnavailable verifies that a failing Neo4j connection reports NOT_SERVING
+func TestHealthCheckNeo4jUnavailable(t *testing.T) {
+	health := NewHealthServer(&fakePinger{err: errors.New("connection refused")}, &fakePinger{}, logging.New(&bytes.Buffer{}, logging.LevelInfo))
+
+	resp, err := health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.Status, "Neo4j failure should report NOT_SERVING")
+}
+
+// TestHealthCheckServing verifies that SERVING is reported when both databases respond
+func TestHealthCheckServing(t *testing.T) {
+	health := NewHealthServer(&fakePinger{}, &fakePinger{}, logging.New(&bytes.Buffer{}, logging.LevelInfo))
+
+	resp, err := health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+}
+
+// TestHealthWatchStatusChange verifies that Watch sends an update when the status changes
+func TestHealthWatchStatusChange(t *testing.T) {
+	neo4j := &fakePinger{}
+	health := NewHealthServer(neo4j, &fakePinger{}, logging.New(&bytes.Buffer{}, logging.LevelInfo))
+	health.interval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &recordingHealthStream{ctx: ctx, sent: make(chan grpc_health_v1.HealthCheckResponse_ServingStatus, 4)}
+	done := make(chan error, 1)
+	go func() { done <- health.Watch(&grpc_health_v1.HealthCheckRequest{}, stream) }()
+
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, <-stream.sent, "Initial status should be SERVING")
+	neo4j.setErr(errors.New("connection refused"))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, <-stream.sent, "Status should change to NOT_SERVING")
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+// TestHealthCheckPingTimeout verifies that a database that does not answer is reported as NOT_SERVING after the ping timeout
+func TestHealthCheckPingTimeout(t *testing.T) {
+	health := NewHealthServer(&fakePinger{}, hangingPinger{}, logging.New(&bytes.Buffer{}, logging.LevelInfo))
+	health.pingTimeout = 20 * time.Millisecond
+
+	start := time.Now()
+	resp, err := health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.Status)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "Expected the check to stop at the ping timeout")
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"lk/datafoundation/crud-api/pkg/logging"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	crudRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "crud_requests_total",
+		Help: "Total number of CRUD service requests by RPC method and status code.",
+	}, []string{"method", "code"})
+
+	crudRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "crud_request_duration_seconds",
+		Help:    "Duration of CRUD service requests by RPC method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	neo4jQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "neo4j_query_duration_seconds",
+		Help:    "Duration of Neo4j queries by query type.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"query"})
+
+	mongoOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mongo_operation_duration_seconds",
+		Help:    "Duration of MongoDB operations by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+)
+
+// prometheusRecorder feeds repository timings into the Prometheus histograms.
+// It implements both the Neo4j and the MongoDB repository MetricsRecorder interfaces.
+type prometheusRecorder struct{}
+
+// ObserveQuery records a Neo4j query duration
+func (prometheusRecorder) ObserveQuery(method string, duration time.Duration, err error) {
+	neo4jQueryDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+// ObserveOperation records a MongoDB operation duration
+func (prometheusRecorder) ObserveOperation(operation string, duration time.Duration, err error) {
+	mongoOperationDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// observeRequest records the outcome and duration of an RPC
+func observeRequest(method string, start time.Time, err error) {
+	crudRequestsTotal.WithLabelValues(method, status.Code(err).String()).Inc()
+	crudRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// MetricsInterceptor counts and times every unary RPC
+func MetricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeRequest(info.FullMethod, start, err)
+	return resp, err
+}
+
+// MetricsStreamInterceptor counts and times every streaming RPC
+func MetricsStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	observeRequest(info.FullMethod, start, err)
+	return err
+}
+
+// serveMetrics exposes the Prometheus metrics on /metrics at the given address
+func serveMetrics(addr string, logger logging.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	logger.Infof("[service.serveMetrics] Metrics are available on %s/metrics", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		logger.Errorf("[service.serveMetrics] Metrics endpoint stopped: %v", err)
+	}
+}
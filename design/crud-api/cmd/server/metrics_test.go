@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// TestMetricsInterceptorCountsCreateEntity tests that a CreateEntity call through the interceptor increments the request counter
+func TestMetricsInterceptorCountsCreateEntity(t *testing.T) {
+	requireDatabases(t)
+	ctx := context.Background()
+	info := &grpc.UnaryServerInfo{FullMethod: "/crud.CrudService/CreateEntity"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.CreateEntity(ctx, req.(*pb.Entity))
+	}
+
+	counter := crudRequestsTotal.WithLabelValues(info.FullMethod, codes.OK.String())
+	before := testutil.ToFloat64(counter)
+
+	entity := &pb.Entity{
+		Id:       "metrics-entity-1",
+		Metadata: map[string]*anypb.Any{},
+	}
+	_, err := MetricsInterceptor(ctx, entity, info, handler)
+	assert.Nil(t, err, "Expected no error when creating the entity")
+
+	assert.Equal(t, before+1, testutil.ToFloat64(counter), "Expected the request counter to increment")
+	assert.Greater(t, testutil.CollectAndCount(crudRequestDuration), 0, "Expected the request duration to be recorded")
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"math"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"lk/datafoundation/crud-api/pkg/logging"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rateLimitIdleTTL is how long a client's limiter is kept after its last request
+const rateLimitIdleTTL = 10 * time.Minute
+
+// clientLimiter is the token bucket of one client IP
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// ipRateLimiter hands out one token bucket per client IP and drops the buckets of idle clients
+type ipRateLimiter struct {
+	mu        sync.Mutex
+	rps       rate.Limit
+	burst     int
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+// limiter returns the token bucket of ip, creating it on the client's first request
+func (l *ipRateLimiter) limiter(ip string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) > rateLimitIdleTTL {
+		for client, c := range l.clients {
+			if now.Sub(c.lastSeen) > rateLimitIdleTTL {
+				delete(l.clients, client)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	c, ok := l.clients[ip]
+	if !ok {
+		c = &clientLimiter{limiter: rate.NewLimiter(l.rps, l.burst)}
+		l.clients[ip] = c
+	}
+	c.lastSeen = now
+	return c.limiter
+}
+
+// RateLimitInterceptor limits each client IP to rps requests per second with bursts of up to burst requests.
+// Requests over the limit fail with codes.ResourceExhausted and a retry-after header giving the seconds to wait.
+// Clients listed in RATE_LIMIT_WHITELIST, a comma-separated list of IPs, are never limited.
+// It logs at the level named by LOG_LEVEL; the Server installs it with its own logger instead.
+func RateLimitInterceptor(rps float64, burst int) grpc.UnaryServerInterceptor {
+	return rateLimitInterceptor(rps, burst, logging.FromEnv())
+}
+
+// rateLimitInterceptor is RateLimitInterceptor writing its log lines to logger
+func rateLimitInterceptor(rps float64, burst int, logger logging.Logger) grpc.UnaryServerInterceptor {
+	limiters := &ipRateLimiter{
+		rps:       rate.Limit(rps),
+		burst:     burst,
+		clients:   make(map[string]*clientLimiter),
+		lastSweep: time.Now(),
+	}
+	trusted := rateLimitWhitelistFromEnv()
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ip := clientIP(ctx)
+		if trusted[ip] {
+			return handler(ctx, req)
+		}
+
+		reservation := limiters.limiter(ip).Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			// Give the token back so rejected requests do not push the client's next slot further out
+			reservation.Cancel()
+			retryAfter := strconv.Itoa(int(math.Ceil(delay.Seconds())))
+			if err := grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter)); err != nil {
+				logger.Warnf("[server.RateLimitInterceptor] Could not set retry-after header: %v", err)
+			}
+			return nil, status.Errorf(codes.ResourceExhausted, "[%s] rate limit exceeded for %s, retry after %ss", info.FullMethod, ip, retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// clientIP returns the IP of the peer that sent the request, or the full peer address when it has no port
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// rateLimitWhitelistFromEnv reads the trusted client IPs from RATE_LIMIT_WHITELIST
+func rateLimitWhitelistFromEnv() map[string]bool {
+	trusted := make(map[string]bool)
+	for _, ip := range strings.Split(os.Getenv("RATE_LIMIT_WHITELIST"), ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			trusted[ip] = true
+		}
+	}
+	return trusted
+}
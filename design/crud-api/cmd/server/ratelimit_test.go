@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// headerRecorder is a grpc.ServerTransportStream that keeps the headers set by an interceptor
+type headerRecorder struct {
+	header metadata.MD
+}
+
+func (r *headerRecorder) Method() string { return pb.CrudService_ReadEntity_FullMethodName }
+
+func (r *headerRecorder) SetHeader(md metadata.MD) error {
+	r.header = metadata.Join(r.header, md)
+	return nil
+}
+
+func (r *headerRecorder) SendHeader(md metadata.MD) error { return r.SetHeader(md) }
+
+func (r *headerRecorder) SetTrailer(md metadata.MD) error { return nil }
+
+// rateLimitedCall sends one request from ip through the interceptor and returns the headers it set and its error
+func rateLimitedCall(interceptor grpc.UnaryServerInterceptor, ip string) (metadata.MD, error) {
+	recorder := &headerRecorder{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), recorder)
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}})
+	info := &grpc.UnaryServerInfo{FullMethod: pb.CrudService_ReadEntity_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+
+	_, err := interceptor(ctx, &pb.ReadEntityRequest{Id: "entity-1"}, info, handler)
+	return recorder.header, err
+}
+
+// TestRateLimitInterceptor verifies that a client over its limit is rejected with a retry-after header
+func TestRateLimitInterceptor(t *testing.T) {
+	interceptor := RateLimitInterceptor(5, 5)
+
+	for i := 0; i < 20; i++ {
+		header, err := rateLimitedCall(interceptor, "10.0.0.1")
+		if i < 5 {
+			assert.NoError(t, err, "Expected request %d to be within the limit", i+1)
+			continue
+		}
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err), "Expected request %d to be rate limited", i+1)
+		assert.Equal(t, []string{"1"}, header.Get("retry-after"), "Expected a retry-after header on request %d", i+1)
+	}
+
+	// Other clients have their own limit
+	_, err := rateLimitedCall(interceptor, "10.0.0.2")
+	assert.NoError(t, err, "Expected a different client not to be limited")
+}
+
+// TestRateLimitInterceptorWhitelist verifies that clients listed in RATE_LIMIT_WHITELIST are never limited
+func TestRateLimitInterceptorWhitelist(t *testing.T) {
+	t.Setenv("RATE_LIMIT_WHITELIST", "10.0.0.3, 10.0.0.4")
+	interceptor := RateLimitInterceptor(5, 5)
+
+	for i := 0; i < 20; i++ {
+		_, err := rateLimitedCall(interceptor, "10.0.0.4")
+		assert.NoError(t, err, "Expected trusted request %d not to be limited", i+1)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultReaperInterval is how often the reaper labels expired entities when no interval is configured
+const defaultReaperInterval = time.Hour
+
+// expiredEntityLabeler is implemented by the graph repository the reaper runs against
+type expiredEntityLabeler interface {
+	LabelExpiredEntities(ctx context.Context) (int, error)
+}
+
+// reaper holds the running reaper goroutine of a Server, if any
+type reaper struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// StartReaper labels entities past their Terminated date every interval until ctx is done or StopReaper is called.
+// A non-positive interval uses defaultReaperInterval. Starting the reaper again replaces the running one.
+func (s *Server) StartReaper(ctx context.Context, repo expiredEntityLabeler, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultReaperInterval
+	}
+	s.StopReaper()
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	s.reaper.mu.Lock()
+	s.reaper.cancel = cancel
+	s.reaper.done = done
+	s.reaper.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				labelled, err := repo.LabelExpiredEntities(ctx)
+				if err != nil {
+					s.logger.Errorf("[service.Reaper] Failed to label expired entities: %v", err)
+					continue
+				}
+				if labelled > 0 {
+					s.logger.Infof("[service.Reaper] Labelled %d expired entities", labelled)
+				}
+			}
+		}
+	}()
+}
+
+// StopReaper stops the running reaper and waits for it to exit. It does nothing if no reaper is running.
+func (s *Server) StopReaper() {
+	s.reaper.mu.Lock()
+	cancel, done := s.reaper.cancel, s.reaper.done
+	s.reaper.cancel, s.reaper.done = nil, nil
+	s.reaper.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
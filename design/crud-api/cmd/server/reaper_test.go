@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	neo4jrepository "lk/datafoundation/crud-api/db/repository/neo4j"
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+	"lk/datafoundation/crud-api/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingLabeler counts how often the reaper runs
+type countingLabeler struct {
+	calls atomic.Int32
+}
+
+func (l *countingLabeler) LabelExpiredEntities(ctx context.Context) (int, error) {
+	l.calls.Add(1)
+	return 0, nil
+}
+
+// TestReaperStops verifies that the reaper runs on every tick and stops running after StopReaper
+func TestReaperStops(t *testing.T) {
+	labeler := &countingLabeler{}
+	reaperServer := NewServer(nil, nil, WithLogger(logging.New(&bytes.Buffer{}, logging.LevelInfo)))
+	reaperServer.StartReaper(context.Background(), labeler, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool { return labeler.calls.Load() >= 2 }, time.Second, 5*time.Millisecond)
+
+	reaperServer.StopReaper()
+	calls := labeler.calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, calls, labeler.calls.Load(), "Expected no runs after StopReaper")
+
+	// Stopping again is a no-op
+	reaperServer.StopReaper()
+}
+
+// TestReaperLabelsExpiredEntity verifies that an entity with a past Terminated date receives the Terminated label
+func TestReaperLabelsExpiredEntity(t *testing.T) {
+	requireDatabases(t)
+	ctx := context.Background()
+	repo, ok := server.neo4jRepo.(*neo4jrepository.Neo4jRepository)
+	if !ok {
+		t.Skip("The test server is not backed by a Neo4jRepository")
+	}
+
+	kind := &pb.Kind{Major: "Organisation", Minor: "Department"}
+	_, err := repo.CreateGraphEntity(ctx, kind, map[string]interface{}{
+		"Id":         "reaper-entity-1",
+		"Name":       "Department of Records",
+		"Created":    "2020-01-01T00:00:00Z",
+		"Terminated": "2021-01-01T00:00:00Z",
+	})
+	assert.NoError(t, err)
+
+	server.StartReaper(ctx, repo, 50*time.Millisecond)
+	defer server.StopReaper()
+
+	// The entity can now be matched by the Terminated label, and still reports its own kind
+	assert.Eventually(t, func() bool {
+		entities, err := repo.FilterEntities(ctx, &pb.Kind{Major: neo4jrepository.TerminatedLabel}, map[string]interface{}{"id": "reaper-entity-1", "include_terminated": true})
+		return err == nil && len(entities) == 1 && entities[0]["kind"] == "Organisation"
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,462 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mongorepository "lk/datafoundation/crud-api/db/repository/mongo"
+	neo4jrepository "lk/datafoundation/crud-api/db/repository/neo4j"
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeDocumentRepository records the entities handed to it instead of writing to MongoDB.
+// Methods not overridden here panic through the nil embedded interface.
+type fakeDocumentRepository struct {
+	mongorepository.DocumentRepository
+	metadataIDs   []string
+	attributeIDs  []string
+	deletedIDs    []string
+	attributesErr error
+
+	// metadata is returned by GetMetadata and replaced by UpdateMetadata, which fails with updateErr when it is set
+	metadata  map[string]map[string]*anypb.Any
+	updateErr error
+
+	// attributes is returned by GetAttributes, limited to the values valid at the requested time
+	attributes map[string]*pb.TimeBasedValueList
+}
+
+func (f *fakeDocumentRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeDocumentRepository) HandleMetadata(ctx context.Context, entityId string, entity *pb.Entity) error {
+	f.metadataIDs = append(f.metadataIDs, entityId)
+	return nil
+}
+
+func (f *fakeDocumentRepository) HandleAttributes(ctx context.Context, entityId string, entity *pb.Entity) error {
+	f.attributeIDs = append(f.attributeIDs, entityId)
+	return f.attributesErr
+}
+
+func (f *fakeDocumentRepository) GetMetadata(ctx context.Context, entityId string) (map[string]*anypb.Any, error) {
+	return f.metadata[entityId], nil
+}
+
+func (f *fakeDocumentRepository) GetAttributes(ctx context.Context, entityId string, asOf string) (map[string]*pb.TimeBasedValueList, error) {
+	if asOf == "" {
+		return f.attributes, nil
+	}
+	at, err := parseTimestamp(asOf)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]*pb.TimeBasedValueList)
+	for key, list := range f.attributes {
+		for _, value := range list.Values {
+			start, _ := parseTimestamp(value.StartTime)
+			end, err := parseTimestamp(value.EndTime)
+			if !at.Before(start) && (err != nil || at.Before(end)) {
+				attributes[key] = &pb.TimeBasedValueList{Values: []*pb.TimeBasedValue{value}}
+			}
+		}
+	}
+	return attributes, nil
+}
+
+func (f *fakeDocumentRepository) UpdateMetadata(ctx context.Context, id string, metadata map[string]*anypb.Any) (*mongo.UpdateResult, error) {
+	if f.updateErr != nil {
+		return nil, f.updateErr
+	}
+	f.metadata[id] = metadata
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (f *fakeDocumentRepository) DeleteEntity(ctx context.Context, id string) (*mongo.DeleteResult, error) {
+	f.deletedIDs = append(f.deletedIDs, id)
+	return &mongo.DeleteResult{DeletedCount: 1}, nil
+}
+
+// fakeGraphRepository records the entities handed to it instead of writing to Neo4j
+type fakeGraphRepository struct {
+	neo4jrepository.GraphRepository
+	entityIDs   []string
+	softDeleted []string
+	hardDeleted []string
+	copied      []string
+	copyErr     error
+}
+
+func (f *fakeGraphRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeGraphRepository) HandleGraphEntityCreationWithRelationships(ctx context.Context, entity *pb.Entity) (bool, error) {
+	f.entityIDs = append(f.entityIDs, entity.Id)
+	return true, nil
+}
+
+func (f *fakeGraphRepository) SoftDeleteGraphEntity(ctx context.Context, entityID string, terminatedAt string) error {
+	f.softDeleted = append(f.softDeleted, entityID)
+	return nil
+}
+
+func (f *fakeGraphRepository) ReadGraphEntity(ctx context.Context, entityID string) (map[string]interface{}, error) {
+	return map[string]interface{}{"Id": entityID}, nil
+}
+
+func (f *fakeGraphRepository) CopyRelationships(ctx context.Context, sourceID, targetID string) (int, error) {
+	if f.copyErr != nil {
+		return 0, f.copyErr
+	}
+	f.copied = append(f.copied, sourceID+"->"+targetID)
+	return 1, nil
+}
+
+func (f *fakeGraphRepository) DeleteGraphEntity(ctx context.Context, entityID string) error {
+	f.hardDeleted = append(f.hardDeleted, entityID)
+	return nil
+}
+
+// TestNewServerCreateEntityWithFakes verifies that CreateEntity writes through the repositories given to NewServer
+func TestNewServerCreateEntityWithFakes(t *testing.T) {
+	docs := &fakeDocumentRepository{}
+	graph := &fakeGraphRepository{}
+	s := NewServer(docs, graph)
+
+	entity := &pb.Entity{
+		Id:      "fake-entity-1",
+		Kind:    &pb.Kind{Major: "Person", Minor: "Employee"},
+		Created: "2025-01-01T00:00:00Z",
+		Name:    &pb.TimeBasedValue{StartTime: "2025-01-01T00:00:00Z"},
+	}
+	resp, err := s.CreateEntity(context.Background(), entity)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Id, resp.Id)
+	assert.Equal(t, []string{"fake-entity-1"}, docs.metadataIDs)
+	assert.Equal(t, []string{"fake-entity-1"}, graph.entityIDs)
+	assert.Equal(t, []string{"fake-entity-1"}, docs.attributeIDs)
+
+	// An attribute write failure is returned to the caller
+	docs.attributesErr = errors.New("attribute store unavailable")
+	_, err = s.CreateEntity(context.Background(), entity)
+	assert.Error(t, err)
+}
+
+// TestDeleteEntitySoftByDefault verifies that DeleteEntity only terminates the graph entity unless HardDelete is set
+func TestDeleteEntitySoftByDefault(t *testing.T) {
+	docs := &fakeDocumentRepository{}
+	graph := &fakeGraphRepository{}
+	s := NewServer(docs, graph)
+
+	_, err := s.DeleteEntity(context.Background(), &pb.DeleteEntityRequest{Id: "fake-entity-2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fake-entity-2"}, graph.softDeleted)
+	assert.Empty(t, graph.hardDeleted, "Expected the node to be kept")
+	assert.Empty(t, docs.deletedIDs, "Expected the document to be kept")
+
+	_, err = s.DeleteEntity(context.Background(), &pb.DeleteEntityRequest{Id: "fake-entity-3", HardDelete: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fake-entity-3"}, graph.hardDeleted)
+	assert.Equal(t, []string{"fake-entity-3"}, docs.deletedIDs)
+	assert.Equal(t, []string{"fake-entity-2"}, graph.softDeleted)
+}
+
+// TestMergeEntitiesLeavesNoPartialMerge verifies that a failed merge does not leave half of its writes behind
+func TestMergeEntitiesLeavesNoPartialMerge(t *testing.T) {
+	sourceValue, err := anypb.New(&pb.Kind{Major: "from source"})
+	assert.NoError(t, err)
+	targetValue, err := anypb.New(&pb.Kind{Major: "from target"})
+	assert.NoError(t, err)
+	newDocs := func() *fakeDocumentRepository {
+		return &fakeDocumentRepository{metadata: map[string]map[string]*anypb.Any{
+			"merge-source": {"sourceOnly": sourceValue},
+			"merge-target": {"targetOnly": targetValue},
+		}}
+	}
+	req := &pb.MergeEntitiesRequest{SourceId: "merge-source", TargetId: "merge-target", Strategy: pb.MergeStrategy_UNION}
+
+	// A failed metadata write happens before any relationship is copied
+	docs := newDocs()
+	docs.updateErr = errors.New("document store unavailable")
+	graph := &fakeGraphRepository{}
+	_, err = NewServer(docs, graph).MergeEntities(context.Background(), req)
+	assert.Error(t, err)
+	assert.Empty(t, graph.copied, "Expected no relationships to be copied")
+
+	// A failed copy puts the target's metadata back
+	docs = newDocs()
+	graph = &fakeGraphRepository{copyErr: errors.New("graph unavailable")}
+	_, err = NewServer(docs, graph).MergeEntities(context.Background(), req)
+	assert.Error(t, err)
+	assert.Equal(t, map[string]*anypb.Any{"targetOnly": targetValue}, docs.metadata["merge-target"], "Expected the target metadata to be restored")
+}
+
+// TestDuplicateEntityValidatesNewId verifies that DuplicateEntity rejects an invalid new Id before reading the source,
+// since the copy is created without going through the ValidationInterceptor
+func TestDuplicateEntityValidatesNewId(t *testing.T) {
+	graph := &fakeGraphRepository{}
+	s := NewServer(&fakeDocumentRepository{}, graph)
+
+	_, err := s.DuplicateEntity(context.Background(), &pb.DuplicateEntityRequest{Id: "fake-entity-4", NewId: "bad id!"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Expected the invalid Id to be rejected")
+	assert.Empty(t, graph.entityIDs, "Expected nothing to be created")
+}
+
+// TestRunStopsOnContextCancel verifies that Run serves until its context is cancelled and then returns nil
+func TestRunStopsOnContextCancel(t *testing.T) {
+	s := NewServer(&fakeDocumentRepository{}, &fakeGraphRepository{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(ctx, "127.0.0.1:0", s)
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
+
+// TestRunInvalidAddress verifies that Run reports a listen failure
+func TestRunInvalidAddress(t *testing.T) {
+	s := NewServer(&fakeDocumentRepository{}, &fakeGraphRepository{})
+	err := Run(context.Background(), "not-an-address", s)
+	assert.Error(t, err)
+}
+
+// readLatency is the simulated round trip of each data source in the ReadEntity tests and benchmarks
+const readLatency = 10 * time.Millisecond
+
+// sleepCtx waits for d, returning early with the context error if ctx is cancelled first
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// slowDocumentRepository serves ReadEntity lookups after a fixed delay
+type slowDocumentRepository struct {
+	fakeDocumentRepository
+	metadataErr error
+}
+
+func (f *slowDocumentRepository) GetMetadata(ctx context.Context, entityId string) (map[string]*anypb.Any, error) {
+	if err := sleepCtx(ctx, readLatency); err != nil {
+		return nil, err
+	}
+	if f.metadataErr != nil {
+		return nil, f.metadataErr
+	}
+	value, _ := anypb.New(&pb.Kind{Major: "meta"})
+	return map[string]*anypb.Any{"source": value}, nil
+}
+
+func (f *slowDocumentRepository) GetAttributes(ctx context.Context, entityId string, asOf string) (map[string]*pb.TimeBasedValueList, error) {
+	if err := sleepCtx(ctx, readLatency); err != nil {
+		return nil, err
+	}
+	return map[string]*pb.TimeBasedValueList{"population": {}}, nil
+}
+
+// slowGraphRepository serves ReadEntity lookups after a fixed delay
+type slowGraphRepository struct {
+	fakeGraphRepository
+}
+
+func (f *slowGraphRepository) GetGraphEntity(ctx context.Context, entityId string) (*pb.Kind, *pb.TimeBasedValue, string, string, error) {
+	if err := sleepCtx(ctx, readLatency); err != nil {
+		return nil, nil, "", "", err
+	}
+	return &pb.Kind{Major: "Person"}, &pb.TimeBasedValue{StartTime: "2025-01-01T00:00:00Z"}, "2025-01-01T00:00:00Z", "", nil
+}
+
+func (f *slowGraphRepository) GetGraphRelationshipsAsOf(ctx context.Context, entityID string, asOf string) (map[string]*pb.Relationship, error) {
+	if err := sleepCtx(ctx, readLatency); err != nil {
+		return nil, err
+	}
+	return map[string]*pb.Relationship{"rel-1": {Id: "rel-1", Name: "KNOWS"}}, nil
+}
+
+// readEntitySequential fetches the same data as ReadEntity one source at a time, as a baseline for the benchmarks
+func readEntitySequential(ctx context.Context, s *Server, req *pb.ReadEntityRequest) *pb.Entity {
+	response := &pb.Entity{Id: req.Id}
+	response.Kind, response.Name, response.Created, response.Terminated, _ = s.neo4jRepo.GetGraphEntity(ctx, req.Id)
+	response.Metadata, _ = s.mongoRepo.GetMetadata(ctx, req.Id)
+	response.Relationships, _ = s.neo4jRepo.GetGraphRelationshipsAsOf(ctx, req.Id, req.AsOf)
+	response.Attributes, _ = s.mongoRepo.GetAttributes(ctx, req.Id, req.AsOf)
+	return response
+}
+
+// readAllRequest asks ReadEntity for every data source
+var readAllRequest = &pb.ReadEntityRequest{
+	Id:     "slow-entity-1",
+	Output: []string{"metadata", "relationships", "attributes"},
+}
+
+// TestReadEntityParallelFetch verifies that ReadEntity fills every requested field and leaves a failing source empty
+func TestReadEntityParallelFetch(t *testing.T) {
+	docs := &slowDocumentRepository{}
+	s := NewServer(docs, &slowGraphRepository{})
+
+	resp, err := s.ReadEntity(context.Background(), readAllRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, "Person", resp.Kind.Major)
+	assert.Contains(t, resp.Metadata, "source")
+	assert.Contains(t, resp.Relationships, "rel-1")
+	assert.Contains(t, resp.Attributes, "population")
+
+	// Unavailable metadata is logged and returned empty instead of failing the read
+	docs.metadataErr = errors.New("metadata store unavailable")
+	resp, err = s.ReadEntity(context.Background(), readAllRequest)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Metadata)
+	assert.Contains(t, resp.Attributes, "population")
+
+	// A cancelled request is reported instead of returning a partial entity
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.ReadEntity(ctx, readAllRequest)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// datedGraphRepository serves ReadEntity lookups for an entity with fixed creation and termination times
+type datedGraphRepository struct {
+	fakeGraphRepository
+	created    string
+	terminated string
+}
+
+func (f *datedGraphRepository) GetGraphEntity(ctx context.Context, entityId string) (*pb.Kind, *pb.TimeBasedValue, string, string, error) {
+	name := &pb.TimeBasedValue{StartTime: f.created, EndTime: f.terminated}
+	return &pb.Kind{Major: "Person"}, name, f.created, f.terminated, nil
+}
+
+// TestReadEntityAsOf verifies that ReadEntity resolves the entity's creation and termination as of the requested time
+func TestReadEntityAsOf(t *testing.T) {
+	s := NewServer(&fakeDocumentRepository{}, &datedGraphRepository{created: "2025-01-01T00:00Z", terminated: "2025-06-01T00:00:00Z"})
+	read := func(asOf string) (*pb.Entity, error) {
+		return s.ReadEntity(context.Background(), &pb.ReadEntityRequest{Id: "dated-entity-1", AsOf: asOf})
+	}
+
+	// 1. An entity created after the as-of time is not found
+	_, err := read("2024-12-31T00:00:00Z")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// 2. A termination after the as-of time is not reported
+	resp, err := read("2025-03-01")
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Terminated)
+	assert.Empty(t, resp.Name.EndTime)
+
+	// 3. A termination before the as-of time is kept
+	resp, err = read("2025-07-01T00:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-06-01T00:00:00Z", resp.Terminated)
+
+	// 4. An unparseable as-of time is rejected
+	_, err = read("yesterday")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// TestReadEntityAsOfName verifies that ReadEntity returns the name valid at the as-of time for an entity renamed twice:
+// 1. Reads the entity at a timestamp inside each of the three name windows
+// 2. Confirms the name of that window is returned instead of the current Neo4j name
+// 3. Confirms the current name is returned without an as-of time
+func TestReadEntityAsOfName(t *testing.T) {
+	names := []string{"Ministry of Technology", "Ministry of Digital Affairs", "Ministry of Digital Economy"}
+	windows := [][2]string{
+		{"2020-01-01T00:00:00Z", "2021-06-01T00:00:00Z"},
+		{"2021-06-01T00:00:00Z", "2023-01-01T00:00:00Z"},
+		{"2023-01-01T00:00:00Z", ""},
+	}
+	history := &pb.TimeBasedValueList{}
+	for i, name := range names {
+		value, err := anypb.New(wrapperspb.String(name))
+		assert.NoError(t, err)
+		history.Values = append(history.Values, &pb.TimeBasedValue{StartTime: windows[i][0], EndTime: windows[i][1], Value: value})
+	}
+	docs := &fakeDocumentRepository{attributes: map[string]*pb.TimeBasedValueList{nameAttribute: history}}
+	s := NewServer(docs, &renamedGraphRepository{name: names[2], created: windows[0][0]})
+
+	nameOf := func(entity *pb.Entity) string {
+		name := &wrapperspb.StringValue{}
+		assert.NoError(t, entity.Name.Value.UnmarshalTo(name))
+		return name.Value
+	}
+
+	for i, asOf := range []string{"2020-05-01T00:00:00Z", "2022-01-01T00:00:00Z", "2024-01-01T00:00:00Z"} {
+		resp, err := s.ReadEntity(context.Background(), &pb.ReadEntityRequest{Id: "renamed-entity-1", AsOf: asOf})
+		assert.NoError(t, err)
+		assert.Equal(t, names[i], nameOf(resp), "Expected the name valid at %s", asOf)
+		assert.Equal(t, windows[i][0], resp.Name.StartTime)
+		assert.Empty(t, resp.Attributes, "Expected attributes to be left out unless requested")
+	}
+
+	resp, err := s.ReadEntity(context.Background(), &pb.ReadEntityRequest{Id: "renamed-entity-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, names[2], nameOf(resp))
+}
+
+// renamedGraphRepository serves ReadEntity lookups for an entity whose Neo4j node only holds its current name
+type renamedGraphRepository struct {
+	fakeGraphRepository
+	name    string
+	created string
+}
+
+func (f *renamedGraphRepository) GetGraphEntity(ctx context.Context, entityId string) (*pb.Kind, *pb.TimeBasedValue, string, string, error) {
+	value, _ := anypb.New(wrapperspb.String(f.name))
+	return &pb.Kind{Major: "Organisation"}, &pb.TimeBasedValue{StartTime: f.created, Value: value}, f.created, "", nil
+}
+
+// TestReadEntityRejectsInvalidRelationshipType verifies that ReadEntity rejects a relationship name that is not a plain
+// identifier before any repository is queried
+func TestReadEntityRejectsInvalidRelationshipType(t *testing.T) {
+	s := NewServer(&slowDocumentRepository{}, &slowGraphRepository{})
+	req := &pb.ReadEntityRequest{
+		Id:     "slow-entity-1",
+		Entity: &pb.Entity{Relationships: map[string]*pb.Relationship{"r": {Name: "KNOWS]->(x) DETACH DELETE x //"}}},
+		Output: []string{"relationships"},
+	}
+
+	_, err := s.ReadEntity(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// BenchmarkReadEntitySequential measures fetching each data source one after another
+func BenchmarkReadEntitySequential(b *testing.B) {
+	s := NewServer(&slowDocumentRepository{}, &slowGraphRepository{})
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		readEntitySequential(ctx, s, readAllRequest)
+	}
+}
+
+// BenchmarkReadEntityParallel measures ReadEntity, which fetches the data sources concurrently
+func BenchmarkReadEntityParallel(b *testing.B) {
+	s := NewServer(&slowDocumentRepository{}, &slowGraphRepository{})
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.ReadEntity(ctx, readAllRequest); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
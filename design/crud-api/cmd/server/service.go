@@ -2,68 +2,153 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
-	"log"
 	"net"
 	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
 
 	"lk/datafoundation/crud-api/db/config"
 	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+	"lk/datafoundation/crud-api/pkg/logging"
 
 	mongorepository "lk/datafoundation/crud-api/db/repository/mongo"
 	neo4jrepository "lk/datafoundation/crud-api/db/repository/neo4j"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
 // Server implements the CrudService
 type Server struct {
 	pb.UnimplementedCrudServiceServer
-	mongoRepo *mongorepository.MongoRepository
-	neo4jRepo *neo4jrepository.Neo4jRepository
+	mongoRepo   mongorepository.DocumentRepository
+	neo4jRepo   neo4jrepository.GraphRepository
+	logger      logging.Logger
+	grpcOptions []grpc.ServerOption
+	timeouts    map[string]time.Duration
+	// rateLimitRPS and rateLimitBurst configure the RateLimitInterceptor installed by Run, which is off while rateLimitRPS is zero
+	rateLimitRPS   float64
+	rateLimitBurst int
+	reaper         reaper
+}
+
+// Option configures optional Server dependencies
+type Option func(*Server)
+
+// WithLogger sets the logger used by the Server's handlers
+func WithLogger(logger logging.Logger) Option {
+	return func(s *Server) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// WithGRPCOptions adds gRPC server options, such as transport credentials, used by Run
+func WithGRPCOptions(opts ...grpc.ServerOption) Option {
+	return func(s *Server) {
+		s.grpcOptions = append(s.grpcOptions, opts...)
+	}
+}
+
+// WithMethodTimeouts bounds the unary RPCs served by Run, keyed by full method name (e.g. /crud.CrudService/CreateEntity)
+func WithMethodTimeouts(timeouts map[string]time.Duration) Option {
+	return func(s *Server) {
+		s.timeouts = timeouts
+	}
+}
+
+// WithRateLimit limits each client IP served by Run to rps unary requests per second, with bursts of up to burst
+func WithRateLimit(rps float64, burst int) Option {
+	return func(s *Server) {
+		s.rateLimitRPS = rps
+		s.rateLimitBurst = burst
+	}
+}
+
+// NewServer creates a Server backed by the given document and graph repositories.
+// Without WithLogger it logs to stderr at the INFO level.
+func NewServer(mongoRepo mongorepository.DocumentRepository, neo4jRepo neo4jrepository.GraphRepository, opts ...Option) *Server {
+	s := &Server{
+		mongoRepo: mongoRepo,
+		neo4jRepo: neo4jRepo,
+		logger:    logging.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // CreateEntity handles entity creation with metadata
 func (s *Server) CreateEntity(ctx context.Context, req *pb.Entity) (*pb.Entity, error) {
-	log.Printf("Creating Entity: %s", req.Id)
+	logger := s.logger.WithField("entity_id", req.Id)
+	logger.Infof("Creating Entity: %s", req.Id)
 
 	// Always save the entity in MongoDB, even if it has no metadata
 	// The HandleMetadata function will only process it if it has metadata
 	err := s.mongoRepo.HandleMetadata(ctx, req.Id, req)
 	if err != nil {
-		log.Printf("[server.CreateEntity] Error saving metadata in MongoDB: %v", err)
+		logger.Errorf("[server.CreateEntity] Error saving metadata in MongoDB: %v", err)
 		return nil, err
 	} else {
-		log.Printf("[server.CreateEntity] Successfully saved metadata in MongoDB for entity: %s", req.Id)
+		logger.Infof("[server.CreateEntity] Successfully saved metadata in MongoDB for entity: %s", req.Id)
 	}
 
-	// Validate required fields for Neo4j entity creation
-	success, err := s.neo4jRepo.HandleGraphEntityCreation(ctx, req)
+	// Save the entity and its relationships in Neo4j in one transaction, so a failed relationship leaves no node behind
+	success, err := s.neo4jRepo.HandleGraphEntityCreationWithRelationships(ctx, req)
 	if !success {
-		log.Printf("[server.CreateEntity] Error saving entity in Neo4j: %v", err)
+		logger.Errorf("[server.CreateEntity] Error saving entity and relationships in Neo4j: %v", err)
 		return nil, err
 	} else {
-		log.Printf("[server.CreateEntity] Successfully saved entity in Neo4j for entity: %s", req.Id)
+		logger.Infof("[server.CreateEntity] Successfully saved entity and relationships in Neo4j for entity: %s", req.Id)
 	}
 
-	// TODO: Add logic to handle relationships
-	err = s.neo4jRepo.HandleGraphRelationshipsCreate(ctx, req)
+	// Save the time based attributes in MongoDB
+	err = s.mongoRepo.HandleAttributes(ctx, req.Id, req)
 	if err != nil {
-		log.Printf("[server.CreateEntity] Error saving relationships in Neo4j: %v", err)
+		logger.Errorf("[server.CreateEntity] Error saving attributes in MongoDB: %v", err)
 		return nil, err
 	} else {
-		log.Printf("[server.CreateEntity] Successfully saved relationships in Neo4j for entity: %s", req.Id)
+		logger.Infof("[server.CreateEntity] Successfully saved attributes in MongoDB for entity: %s", req.Id)
 	}
 
-	// TODO: Add logic to handle attributes
 	return req, nil
 }
 
-// ReadEntity retrieves an entity's metadata
+// ReadEntity retrieves an entity's metadata.
+// With AsOf, an entity created after that time is not found and a termination after it is not reported. Neo4j only
+// keeps the latest Name of an entity, so the name valid at AsOf is taken from the entity's name attribute, which holds
+// its name history. Entities without a name history keep their current name.
 func (s *Server) ReadEntity(ctx context.Context, req *pb.ReadEntityRequest) (*pb.Entity, error) {
-	log.Printf(">>>> Reading Entity: %s with output fields: %v", req.Id, req.Output)
+	logger := s.logger.WithField("entity_id", req.Id)
+	logger.Infof(">>>> Reading Entity: %s with output fields: %v (as of: %s)", req.Id, req.Output, req.AsOf)
+
+	var asOf time.Time
+	if req.AsOf != "" {
+		parsed, err := parseTimestamp(req.AsOf)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "[server.ReadEntity] invalid as-of time %q: %v", req.AsOf, err)
+		}
+		asOf = parsed
+	}
+	if req.Entity != nil {
+		for _, rel := range req.Entity.Relationships {
+			if err := validateRelationshipType(rel.Name); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "[server.ReadEntity] %v", err)
+			}
+		}
+	}
 
 	// Initialize a complete response entity with empty fields
 	response := &pb.Entity{
@@ -77,99 +162,186 @@ func (s *Server) ReadEntity(ctx context.Context, req *pb.ReadEntityRequest) (*pb
 		Relationships: make(map[string]*pb.Relationship),
 	}
 
+	// Collect the requested output fields, each one is fetched once
+	requested := make(map[string]bool)
+	for _, field := range req.Output {
+		switch field {
+		case "metadata", "relationships", "attributes":
+			requested[field] = true
+		case "kind", "name", "created", "terminated":
+			// These fields are always fetched with the basic entity info
+			continue
+		default:
+			logger.Warnf("Unknown output field requested: %s", field)
+		}
+	}
+
+	// Validate that all requested relationships have a Name field before fetching anything
+	if requested["relationships"] && req.Entity != nil {
+		for _, rel := range req.Entity.Relationships {
+			if rel.Name == "" {
+				return nil, fmt.Errorf("invalid relationship: all relationships must have a Name field")
+			}
+		}
+	}
+
+	// The data sources are independent, so they are fetched concurrently. Each goroutine only writes
+	// its own fields of the response, and a failing source leaves its fields empty.
+	var wg sync.WaitGroup
+	fetch := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+
 	// Always fetch basic entity info from Neo4j
-	kind, name, created, terminated, err := s.neo4jRepo.GetGraphEntity(ctx, req.Id)
-	if err != nil {
-		log.Printf("Error fetching entity info: %v", err)
-		// Continue processing as we might still be able to get other information
-	} else {
+	fetch(func() {
+		kind, name, created, terminated, err := s.neo4jRepo.GetGraphEntity(ctx, req.Id)
+		if err != nil {
+			logger.Errorf("Error fetching entity info: %v", err)
+			// Continue processing as we might still be able to get other information
+			return
+		}
 		response.Kind = kind
 		response.Name = name
 		response.Created = created
 		response.Terminated = terminated
-	}
+	})
 
-	// If no output fields specified, return the entity with basic info
-	if len(req.Output) == 0 {
-		return response, nil
-	}
-
-	// Process each requested output field
-	for _, field := range req.Output {
-		log.Printf("[DEBUG] Entering switch statement for entity ID: %s", req.Id)
-		switch field {
-		case "metadata":
-			log.Printf("[DEBUG] Processing metadata field for entity ID: %s", req.Id)
+	if requested["metadata"] {
+		fetch(func() {
+			logger.Debugf("Processing metadata field for entity ID: %s", req.Id)
 			// Get metadata from MongoDB
 			metadata, err := s.mongoRepo.GetMetadata(ctx, req.Id)
 			if err != nil {
-				log.Printf("Error fetching metadata: %v", err)
-				// Continue with other fields even if metadata fails
-			} else {
-				log.Printf("[DEBUG] Retrieved metadata: %+v", metadata)
-				response.Metadata = metadata
+				logger.Errorf("Error fetching metadata: %v", err)
+				return
 			}
+			logger.Debugf("Retrieved metadata: %+v", metadata)
+			response.Metadata = metadata
+		})
+	}
+
+	if requested["relationships"] {
+		fetch(func() {
+			response.Relationships = s.readRelationships(ctx, logger, req)
+		})
+	}
 
-		case "relationships":
-			// Handle relationships based on the input entity
-			if req.Entity != nil && len(req.Entity.Relationships) > 0 {
-				// Case 1: Validate that all relationships have a Name field
-				for _, rel := range req.Entity.Relationships {
-					if rel.Name == "" {
-						return nil, fmt.Errorf("invalid relationship: all relationships must have a Name field")
-					}
-				}
-
-				// Case 2: Call GetRelationshipsByName for each relationship
-				for _, rel := range req.Entity.Relationships {
-					log.Printf("Fetching related entity IDs for entity %s with relationship %s and start time %s", req.Id, rel.Name, rel.StartTime)
-					relsByName, err := s.neo4jRepo.GetRelationshipsByName(ctx, req.Id, rel.Name, rel.StartTime)
-					if err != nil {
-						log.Printf("Error fetching related entity IDs for entity %s: %v", req.Id, err)
-						continue // Continue with other relationships even if one fails
-					}
-
-					// Add the relationships to the response
-					for id, relationship := range relsByName {
-						response.Relationships[id] = relationship
-					}
-				}
-			} else {
-				// Case 3: If no specific relationships requested, get all relationships
-				log.Printf("Fetching all relationships for entity %s", req.Id)
-				graphRelationships, err := s.neo4jRepo.GetGraphRelationships(ctx, req.Id)
-				if err != nil {
-					log.Printf("Error fetching relationships for entity %s: %v", req.Id, err)
-					// Continue with other fields even if relationships fail
-				} else {
-					response.Relationships = graphRelationships
-				}
+	// With AsOf the attributes are also needed for the name history, even when they are not requested
+	var attributes map[string]*pb.TimeBasedValueList
+	if requested["attributes"] || req.AsOf != "" {
+		fetch(func() {
+			// Get attributes from MongoDB, restricted to the values valid at AsOf when given
+			var err error
+			attributes, err = s.mongoRepo.GetAttributes(ctx, req.Id, req.AsOf)
+			if err != nil {
+				logger.Errorf("Error fetching attributes: %v", err)
+				attributes = nil
 			}
+		})
+	}
 
-		case "attributes":
-			// TODO: Implement attribute fetching when available
-			log.Printf("Attribute fetching not yet implemented")
-			// Attributes map is already initialized
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if requested["attributes"] && attributes != nil {
+		response.Attributes = attributes
+	}
+	if req.AsOf != "" {
+		if err := entityAsOf(response, asOf); err != nil {
+			return nil, status.Errorf(codes.NotFound, "[server.ReadEntity] %v", err)
+		}
+		if names := attributes[nameAttribute]; names != nil && len(names.Values) > 0 {
+			response.Name = names.Values[0]
+		}
+	}
+	return response, nil
+}
 
-		case "kind", "name", "created", "terminated":
-			// These fields are already fetched at the start
-			continue
+// nameAttribute is the attribute holding the name history of an entity, one value per time window
+const nameAttribute = "name"
 
-		default:
-			log.Printf("Unknown output field requested: %s", field)
+// entityAsOf resolves the basic entity info read from Neo4j as of the given time. It fails when the entity was created
+// after asOf, and clears a termination that happened after it. Dates that cannot be parsed are left as they are.
+func entityAsOf(entity *pb.Entity, asOf time.Time) error {
+	created, err := parseTimestamp(entity.Created)
+	if err != nil {
+		return nil
+	}
+	if asOf.Before(created) {
+		return fmt.Errorf("entity %s did not exist at %s", entity.Id, asOf.Format(time.RFC3339))
+	}
+
+	terminated, err := parseTimestamp(entity.Terminated)
+	if err == nil && asOf.Before(terminated) {
+		entity.Terminated = ""
+		if entity.Name != nil {
+			entity.Name.EndTime = ""
 		}
 	}
+	return nil
+}
 
-	return response, nil
+// parseTimestamp parses an RFC3339 timestamp, a date, or a timestamp without seconds as Neo4j formats whole minutes
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04Z07:00"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// readRelationships fetches the relationships requested by ReadEntity. Relationships named in the request
+// entity are read by name, otherwise all outgoing relationships are read. Failures are logged and skipped.
+func (s *Server) readRelationships(ctx context.Context, logger logging.Logger, req *pb.ReadEntityRequest) map[string]*pb.Relationship {
+	relationships := make(map[string]*pb.Relationship)
+
+	// If no specific relationships are requested, get all relationships
+	if req.Entity == nil || len(req.Entity.Relationships) == 0 {
+		logger.Debugf("Fetching all relationships for entity %s", req.Id)
+		graphRelationships, err := s.neo4jRepo.GetGraphRelationshipsAsOf(ctx, req.Id, req.AsOf)
+		if err != nil {
+			logger.Errorf("Error fetching relationships for entity %s: %v", req.Id, err)
+			return relationships
+		}
+		return graphRelationships
+	}
+
+	// Call GetRelationshipsByName for each relationship
+	for _, rel := range req.Entity.Relationships {
+		// Prefer the requested point in time over the relationship's start time
+		ts := rel.StartTime
+		if req.AsOf != "" {
+			ts = req.AsOf
+		}
+		logger.Debugf("Fetching related entity IDs for entity %s with relationship %s and start time %s", req.Id, rel.Name, ts)
+		relsByName, err := s.neo4jRepo.GetRelationshipsByName(ctx, req.Id, rel.Name, ts)
+		if err != nil {
+			logger.Errorf("Error fetching related entity IDs for entity %s: %v", req.Id, err)
+			continue // Continue with other relationships even if one fails
+		}
+
+		// Add the relationships to the response
+		for id, relationship := range relsByName {
+			relationships[id] = relationship
+		}
+	}
+	return relationships
 }
 
 // UpdateEntity modifies existing metadata
 func (s *Server) UpdateEntity(ctx context.Context, req *pb.UpdateEntityRequest) (*pb.Entity, error) {
+	logger := s.logger.WithField("entity_id", req.Id)
 	// Extract ID from request parameter and entity data
 	updateEntityID := req.Id
 	updateEntity := req.Entity
 
-	log.Printf("[server.UpdateEntity] Updating Entity: %s", updateEntityID)
+	logger.Infof("[server.UpdateEntity] Updating Entity: %s", updateEntityID)
 
 	// Initialize metadata
 	var metadata map[string]*anypb.Any
@@ -178,24 +350,34 @@ func (s *Server) UpdateEntity(ctx context.Context, req *pb.UpdateEntityRequest)
 	err := s.mongoRepo.HandleMetadata(ctx, updateEntityID, updateEntity)
 	if err != nil {
 		// Log error and continue with empty metadata
-		log.Printf("[server.UpdateEntity] Error updating metadata for entity %s: %v", updateEntityID, err)
+		logger.Errorf("[server.UpdateEntity] Error updating metadata for entity %s: %v", updateEntityID, err)
 		metadata = make(map[string]*anypb.Any)
 	} else {
 		// Use the provided metadata
 		metadata = updateEntity.Metadata
 	}
 
+	// Handle attributes update
+	attributes := make(map[string]*pb.TimeBasedValueList)
+	err = s.mongoRepo.HandleAttributes(ctx, updateEntityID, updateEntity)
+	if err != nil {
+		logger.Errorf("[server.UpdateEntity] Error updating attributes for entity %s: %v", updateEntityID, err)
+		// Continue processing despite error
+	} else if updateEntity.Attributes != nil {
+		attributes = updateEntity.Attributes
+	}
+
 	// Handle Graph Entity update if entity has required fields
 	success, err := s.neo4jRepo.HandleGraphEntityUpdate(ctx, updateEntity)
 	if !success {
-		log.Printf("[server.UpdateEntity] Error updating graph entity for %s: %v", updateEntityID, err)
+		logger.Errorf("[server.UpdateEntity] Error updating graph entity for %s: %v", updateEntityID, err)
 		// Continue processing despite error
 	}
 
 	// Handle Relationships update
 	err = s.neo4jRepo.HandleGraphRelationshipsUpdate(ctx, updateEntity)
 	if err != nil {
-		log.Printf("[server.UpdateEntity] Error updating relationships for entity %s: %v", updateEntityID, err)
+		logger.Errorf("[server.UpdateEntity] Error updating relationships for entity %s: %v", updateEntityID, err)
 		// Continue processing despite error
 	}
 
@@ -213,39 +395,350 @@ func (s *Server) UpdateEntity(ctx context.Context, req *pb.UpdateEntityRequest)
 		Created:       created,
 		Terminated:    terminated,
 		Metadata:      metadata,
-		Attributes:    make(map[string]*pb.TimeBasedValueList), // Empty attributes
-		Relationships: relationships,
+		Attributes:    attributes,
+		Relationships: neo4jrepository.OutgoingRelationships(relationships),
 	}, nil
 }
 
-// DeleteEntity removes metadata
-func (s *Server) DeleteEntity(ctx context.Context, req *pb.EntityId) (*pb.Empty, error) {
-	log.Printf("[server.DeleteEntity] Deleting Entity metadata: %s", req.Id)
+// DeleteEntity soft-deletes an entity by stamping its Terminated date in Neo4j. With HardDelete set, its document is
+// removed from MongoDB and its node from Neo4j instead, which fails while the node still has relationships.
+func (s *Server) DeleteEntity(ctx context.Context, req *pb.DeleteEntityRequest) (*pb.Empty, error) {
+	logger := s.logger.WithField("entity_id", req.Id)
+	if !req.HardDelete {
+		// Soft delete keeps the entity's data and history, stamping it as terminated now
+		terminated := time.Now().UTC().Format(time.RFC3339)
+		logger.Infof("[server.DeleteEntity] Soft-deleting Entity: %s as of %s", req.Id, terminated)
+		if err := s.neo4jRepo.SoftDeleteGraphEntity(ctx, req.Id, terminated); err != nil {
+			logger.Errorf("[server.DeleteEntity] Error soft-deleting entity %s: %v", req.Id, err)
+			return nil, err
+		}
+		return &pb.Empty{}, nil
+	}
+
+	logger.Infof("[server.DeleteEntity] Deleting Entity metadata: %s", req.Id)
 	_, err := s.mongoRepo.DeleteEntity(ctx, req.Id)
 	if err != nil {
-		// Log error but return success
-		log.Printf("[server.DeleteEntity] Error deleting metadata for entity %s: %v", req.Id, err)
+		// Log error but continue with the graph entity
+		logger.Errorf("[server.DeleteEntity] Error deleting metadata for entity %s: %v", req.Id, err)
+	}
+	logger.Infof("[server.DeleteEntity] Deleting graph entity: %s", req.Id)
+	if err := s.neo4jRepo.DeleteGraphEntity(ctx, req.Id); err != nil {
+		logger.Errorf("[server.DeleteEntity] Error deleting graph entity %s: %v", req.Id, err)
+		return nil, err
 	}
-	// TODO: Implement Relationship Deletion in Neo4j
-	// TODO: Implement Entity Deletion in Neo4j
-	// TODO: Implement Attribute Deletion in Neo4j
 	return &pb.Empty{}, nil
 }
 
+// MergeEntities merges the source entity into the target entity. The metadata of both is combined according to the
+// strategy and stored on the target, the source's relationships are copied onto the target, and the source and its
+// open relationships are terminated when requested. If the relationships cannot be copied the target's metadata is restored.
+// It returns the updated target entity.
+func (s *Server) MergeEntities(ctx context.Context, req *pb.MergeEntitiesRequest) (*pb.Entity, error) {
+	logger := s.logger.WithField("entity_id", req.TargetId)
+	logger.Infof("[server.MergeEntities] Merging entity %s into %s with strategy %s", req.SourceId, req.TargetId, req.Strategy)
+
+	if req.SourceId == req.TargetId {
+		return nil, fmt.Errorf("cannot merge entity %s into itself", req.SourceId)
+	}
+	// Both entities must exist before anything is written
+	for _, id := range []string{req.SourceId, req.TargetId} {
+		if _, err := s.neo4jRepo.ReadGraphEntity(ctx, id); err != nil {
+			logger.Errorf("[server.MergeEntities] Error reading entity %s: %v", id, err)
+			return nil, err
+		}
+	}
+
+	sourceMetadata, err := s.mongoRepo.GetMetadata(ctx, req.SourceId)
+	if err != nil {
+		logger.Errorf("[server.MergeEntities] Error fetching metadata for source entity %s: %v", req.SourceId, err)
+		return nil, err
+	}
+	targetMetadata, err := s.mongoRepo.GetMetadata(ctx, req.TargetId)
+	if err != nil {
+		logger.Errorf("[server.MergeEntities] Error fetching metadata for target entity %s: %v", req.TargetId, err)
+		return nil, err
+	}
+
+	merged, err := mergeMetadata(sourceMetadata, targetMetadata, req.Strategy)
+	if err != nil {
+		logger.Errorf("[server.MergeEntities] Error merging metadata: %v", err)
+		return nil, err
+	}
+	if _, err := s.mongoRepo.UpdateMetadata(ctx, req.TargetId, merged); err != nil {
+		logger.Errorf("[server.MergeEntities] Error saving merged metadata for entity %s: %v", req.TargetId, err)
+		return nil, err
+	}
+
+	// The relationships are copied in one transaction, so on failure only the metadata needs to be put back
+	copied, err := s.neo4jRepo.CopyRelationships(ctx, req.SourceId, req.TargetId)
+	if err != nil {
+		logger.Errorf("[server.MergeEntities] Error copying relationships to entity %s: %v", req.TargetId, err)
+		if _, restoreErr := s.mongoRepo.UpdateMetadata(ctx, req.TargetId, targetMetadata); restoreErr != nil {
+			logger.Errorf("[server.MergeEntities] Error restoring metadata for entity %s: %v", req.TargetId, restoreErr)
+		}
+		return nil, err
+	}
+	logger.Infof("[server.MergeEntities] Copied %d relationships from %s to %s", copied, req.SourceId, req.TargetId)
+
+	if req.TerminateSource {
+		terminated := time.Now().UTC().Format(time.RFC3339)
+		// The copied relationships now live on the target, so the source's own copies are ended with it
+		if _, err := s.neo4jRepo.TerminateGraphEntity(ctx, req.SourceId, terminated); err != nil {
+			logger.Errorf("[server.MergeEntities] Error terminating source entity %s: %v", req.SourceId, err)
+			return nil, err
+		}
+	}
+
+	return s.assembleEntity(ctx, req.TargetId)
+}
+
+// DuplicateEntity copies an entity, its metadata and attributes under a new Id, optionally with its outgoing
+// relationships. The copy is created now, so its Created timestamp is the current time.
+func (s *Server) DuplicateEntity(ctx context.Context, req *pb.DuplicateEntityRequest) (*pb.Entity, error) {
+	newID := req.NewId
+	if newID == "" {
+		var err error
+		if newID, err = newEntityId(); err != nil {
+			return nil, err
+		}
+	}
+	// The copy is created by calling CreateEntity directly, which bypasses the ValidationInterceptor
+	if err := validateEntityId(newID); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "[server.DuplicateEntity] %v", err)
+	}
+	logger := s.logger.WithField("entity_id", newID)
+	logger.Infof("[server.DuplicateEntity] Duplicating entity %s as %s", req.Id, newID)
+
+	if newID == req.Id {
+		return nil, fmt.Errorf("cannot duplicate entity %s onto itself", req.Id)
+	}
+
+	kind, name, _, _, err := s.neo4jRepo.GetGraphEntity(ctx, req.Id)
+	if err != nil {
+		logger.Errorf("[server.DuplicateEntity] Error reading source entity %s: %v", req.Id, err)
+		return nil, err
+	}
+	if kind == nil {
+		return nil, fmt.Errorf("entity %s not found", req.Id)
+	}
+	metadata, err := s.mongoRepo.GetMetadata(ctx, req.Id)
+	if err != nil {
+		logger.Errorf("[server.DuplicateEntity] Error fetching metadata for source entity %s: %v", req.Id, err)
+		return nil, err
+	}
+	// Entities without a MongoDB document simply have no attributes
+	attributes, err := s.mongoRepo.GetAttributes(ctx, req.Id, "")
+	if err != nil {
+		attributes = make(map[string]*pb.TimeBasedValueList)
+	}
+
+	created := time.Now().UTC().Format(time.RFC3339)
+	duplicate := &pb.Entity{
+		Id:         newID,
+		Kind:       proto.Clone(kind).(*pb.Kind),
+		Created:    created,
+		Metadata:   make(map[string]*anypb.Any, len(metadata)),
+		Attributes: make(map[string]*pb.TimeBasedValueList, len(attributes)),
+	}
+	if name != nil && name.Value != nil {
+		duplicate.Name = &pb.TimeBasedValue{StartTime: created, Value: proto.Clone(name.Value).(*anypb.Any)}
+	}
+	// Clone every value so the copy shares no messages with the source
+	for key, value := range metadata {
+		duplicate.Metadata[key] = proto.Clone(value).(*anypb.Any)
+	}
+	for key, values := range attributes {
+		duplicate.Attributes[key] = proto.Clone(values).(*pb.TimeBasedValueList)
+	}
+
+	if req.CopyRelationships {
+		relationships, err := s.neo4jRepo.GetGraphRelationships(ctx, req.Id)
+		if err != nil {
+			logger.Errorf("[server.DuplicateEntity] Error reading relationships of source entity %s: %v", req.Id, err)
+			return nil, err
+		}
+		duplicate.Relationships = make(map[string]*pb.Relationship)
+		for _, rel := range neo4jrepository.OutgoingRelationships(relationships) {
+			copied := proto.Clone(rel).(*pb.Relationship)
+			copied.Id = rel.Id + "_" + newID
+			duplicate.Relationships[copied.Id] = copied
+		}
+	}
+
+	return s.CreateEntity(ctx, duplicate)
+}
+
+// newEntityId returns a random version 4 UUID
+func newEntityId() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate entity Id: %v", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// mergeMetadata combines source metadata into target metadata according to the strategy.
+// OVERWRITE keeps the target value on conflict, PRESERVE lets the source fill target values that are missing or empty,
+// and UNION combines both maps but fails when a key holds different values in each.
+func mergeMetadata(source, target map[string]*anypb.Any, strategy pb.MergeStrategy) (map[string]*anypb.Any, error) {
+	merged := make(map[string]*anypb.Any, len(source)+len(target))
+	for key, value := range target {
+		merged[key] = value
+	}
+
+	for key, value := range source {
+		existing, exists := merged[key]
+		switch strategy {
+		case pb.MergeStrategy_OVERWRITE:
+			if !exists {
+				merged[key] = value
+			}
+		case pb.MergeStrategy_PRESERVE:
+			if !exists || existing == nil || len(existing.Value) == 0 {
+				merged[key] = value
+			}
+		case pb.MergeStrategy_UNION:
+			if exists && !proto.Equal(existing, value) {
+				return nil, fmt.Errorf("metadata key %q has conflicting values in source and target", key)
+			}
+			merged[key] = value
+		default:
+			return nil, fmt.Errorf("unknown merge strategy %v", strategy)
+		}
+	}
+
+	return merged, nil
+}
+
+// streamPageSize is the number of entities StreamEntities fetches from Neo4j at a time
+const streamPageSize = 50
+
+// StreamEntities streams every entity matching the filter, fully assembled, paging through the matches
+// so that only one page is held in memory. It stops as soon as the client cancels the stream.
+func (s *Server) StreamEntities(req *pb.FilterEntitiesRequest, stream pb.CrudService_StreamEntitiesServer) error {
+	ctx := stream.Context()
+	s.logger.Infof("[server.StreamEntities] Streaming entities of kind: %v", req.Kind)
+
+	streamed := 0
+	for skip := 0; ; skip += streamPageSize {
+		if err := ctx.Err(); err != nil {
+			s.logger.Infof("[server.StreamEntities] Stream cancelled after %d entities: %v", streamed, err)
+			return err
+		}
+
+		filters := map[string]interface{}{
+			"id":                 req.Id,
+			"name":               req.Name,
+			"created":            req.Created,
+			"terminated":         req.Terminated,
+			"include_terminated": req.IncludeTerminated,
+			"skip":               skip,
+			"limit":              streamPageSize,
+		}
+
+		entities, err := s.neo4jRepo.FilterEntities(ctx, req.Kind, filters)
+		if err != nil {
+			s.logger.Errorf("[server.StreamEntities] Error filtering entities: %v", err)
+			return err
+		}
+
+		for _, entity := range entities {
+			entityId, ok := entity["id"].(string)
+			if !ok {
+				continue
+			}
+
+			// Stop early instead of reading entities for a client that has gone away
+			if err := ctx.Err(); err != nil {
+				s.logger.Infof("[server.StreamEntities] Stream cancelled after %d entities: %v", streamed, err)
+				return err
+			}
+
+			response, err := s.assembleEntity(ctx, entityId)
+			if err != nil {
+				s.logger.Errorf("[server.StreamEntities] Error reading entity %s: %v", entityId, err)
+				return err
+			}
+
+			if err := stream.Send(response); err != nil {
+				s.logger.Errorf("[server.StreamEntities] Error sending entity %s: %v", entityId, err)
+				return err
+			}
+			streamed++
+		}
+
+		if len(entities) < streamPageSize {
+			break
+		}
+	}
+
+	s.logger.Infof("[server.StreamEntities] Streamed %d entities", streamed)
+	return nil
+}
+
+// assembleEntity reads the graph information, relationships and metadata of an entity
+func (s *Server) assembleEntity(ctx context.Context, entityId string) (*pb.Entity, error) {
+	kind, name, created, terminated, err := s.neo4jRepo.GetGraphEntity(ctx, entityId)
+	if err != nil {
+		return nil, err
+	}
+
+	relationships, err := s.neo4jRepo.GetGraphRelationships(ctx, entityId)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := s.mongoRepo.GetMetadata(ctx, entityId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entities without a MongoDB document simply have no attributes
+	attributes, err := s.mongoRepo.GetAttributes(ctx, entityId, "")
+	if err != nil {
+		attributes = make(map[string]*pb.TimeBasedValueList)
+	}
+
+	return &pb.Entity{
+		Id:            entityId,
+		Kind:          kind,
+		Name:          name,
+		Created:       created,
+		Terminated:    terminated,
+		Metadata:      metadata,
+		Attributes:    attributes,
+		Relationships: neo4jrepository.OutgoingRelationships(relationships),
+	}, nil
+}
+
 // Start the gRPC server
 func main() {
+	// LOG_LEVEL selects the least severe level written: DEBUG, INFO (default), WARN or ERROR
+	logger := logging.FromEnv()
+
 	// Initialize MongoDB config
 	mongoConfig := &config.MongoConfig{
 		URI:        os.Getenv("MONGO_URI"),
 		DBName:     os.Getenv("MONGO_DB_NAME"),
 		Collection: os.Getenv("MONGO_COLLECTION"),
+		EntityTTL:  getEnvDuration(logger, "MONGO_ENTITY_TTL"),
 	}
 
 	// Initialize Neo4j config
 	neo4jConfig := &config.Neo4jConfig{
-		URI:      os.Getenv("NEO4J_URI"),
-		Username: os.Getenv("NEO4J_USER"),
-		Password: os.Getenv("NEO4J_PASSWORD"),
+		URI:                          os.Getenv("NEO4J_URI"),
+		Username:                     os.Getenv("NEO4J_USER"),
+		Password:                     os.Getenv("NEO4J_PASSWORD"),
+		MaxConnectionPoolSize:        getEnvInt(logger, "NEO4J_MAX_CONNECTION_POOL_SIZE"),
+		MaxConnectionLifetime:        getEnvDuration(logger, "NEO4J_MAX_CONNECTION_LIFETIME"),
+		ConnectionAcquisitionTimeout: getEnvDuration(logger, "NEO4J_CONNECTION_ACQUISITION_TIMEOUT"),
+		SocketConnectTimeout:         getEnvDuration(logger, "NEO4J_SOCKET_CONNECT_TIMEOUT"),
+		MaxConcurrentSessions:        getEnvInt(logger, "NEO4J_MAX_CONCURRENT_SESSIONS"),
+		QueryTimeout:                 getEnvDuration(logger, "NEO4J_QUERY_TIMEOUT"),
+		MaxNeighborhoodDepth:         getEnvInt(logger, "NEO4J_MAX_NEIGHBORHOOD_DEPTH"),
+		TenantIsolationMode:          config.TenantIsolationMode(os.Getenv("NEO4J_TENANT_ISOLATION_MODE")),
 	}
 
 	// Get host and port from environment variables with defaults
@@ -257,36 +750,158 @@ func main() {
 	if port == "" {
 		port = "50051"
 	}
+	metricsPort := os.Getenv("CRUD_METRICS_PORT")
+	if metricsPort == "" {
+		metricsPort = "9091"
+	}
+
+	// Stop serving gracefully on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Trace repository operations when an OTLP endpoint is configured
+	shutdownTracing, err := setupTracing(ctx)
+	if err != nil {
+		logger.Errorf("[service.main] Failed to configure tracing: %v", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Create MongoDB repository
-	ctx := context.Background()
-	mongoRepo := mongorepository.NewMongoRepository(ctx, mongoConfig)
+	mongoRepo := mongorepository.NewMongoRepository(ctx, mongoConfig, logger)
 
 	// Create Neo4j repository
-	neo4jRepo, err := neo4jrepository.NewNeo4jRepository(ctx, neo4jConfig)
+	neo4jRepo, err := neo4jrepository.NewNeo4jRepository(ctx, neo4jConfig, logger)
+	if err != nil {
+		logger.Errorf("[service.main] Failed to create Neo4j repository: %v", err)
+		os.Exit(1)
+	}
+	defer neo4jRepo.Close(context.Background())
+
+	// Record repository timings and expose them alongside the gRPC listener
+	mongoRepo.SetMetricsRecorder(prometheusRecorder{})
+	neo4jRepo.SetMetricsRecorder(prometheusRecorder{})
+	go serveMetrics(host+":"+metricsPort, logger)
+
+	tlsOption, err := tlsServerOption(logger)
 	if err != nil {
-		log.Fatalf("[service.main] Failed to create Neo4j repository: %v", err)
+		logger.Errorf("[service.main] Failed to configure TLS: %v", err)
+		os.Exit(1)
+	}
+	opts := []Option{WithLogger(logger), WithMethodTimeouts(methodTimeoutsFromEnv(logger))}
+	if tlsOption != nil {
+		opts = append(opts, WithGRPCOptions(tlsOption))
+	}
+	// Limit each client IP when RATE_LIMIT_RPS is set, with bursts of RATE_LIMIT_BURST requests (default 1)
+	if rps := getEnvFloat(logger, "RATE_LIMIT_RPS"); rps > 0 {
+		burst := getEnvInt(logger, "RATE_LIMIT_BURST")
+		if burst <= 0 {
+			burst = 1
+		}
+		opts = append(opts, WithRateLimit(rps, burst))
 	}
-	defer neo4jRepo.Close(ctx)
 
-	listener, err := net.Listen("tcp", host+":"+port)
+	server := NewServer(mongoRepo, neo4jRepo, opts...)
+
+	// Label entities once their Terminated date passes
+	server.StartReaper(ctx, neo4jRepo, getEnvDuration(logger, "CRUD_REAPER_INTERVAL"))
+	defer server.StopReaper()
+
+	if err := Run(ctx, host+":"+port, server); err != nil {
+		logger.Errorf("[service.main] Failed to serve: %v", err)
+		os.Exit(1)
+	}
+}
+
+// Run listens on addr and serves the CRUD, health and reflection services until ctx is done,
+// then stops gracefully. It returns nil after a graceful stop.
+func Run(ctx context.Context, addr string, s *Server) error {
+	listener, err := net.Listen("tcp", addr)
 	if err != nil {
-		log.Fatalf("[service.main] Failed to listen: %v", err)
+		return fmt.Errorf("failed to listen on %s: %v", addr, err)
 	}
 
-	grpcServer := grpc.NewServer()
-	server := &Server{
-		mongoRepo: mongoRepo,
-		neo4jRepo: neo4jRepo,
+	unaryInterceptors := []grpc.UnaryServerInterceptor{MetricsInterceptor}
+	if s.rateLimitRPS > 0 {
+		unaryInterceptors = append(unaryInterceptors, rateLimitInterceptor(s.rateLimitRPS, s.rateLimitBurst, s.logger))
 	}
+	unaryInterceptors = append(unaryInterceptors, NewTimeoutInterceptor(s.timeouts, s.logger), TenantInterceptor, ValidationInterceptor)
+
+	serverOptions := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(MetricsStreamInterceptor, TenantStreamInterceptor),
+	}
+	serverOptions = append(serverOptions, s.grpcOptions...)
+	grpcServer := grpc.NewServer(serverOptions...)
+
+	pb.RegisterCrudServiceServer(grpcServer, s)
 
-	pb.RegisterCrudServiceServer(grpcServer, server)
+	// Register the standard health service. A Kubernetes liveness probe can target it with:
+	//   livenessProbe:
+	//     grpc:
+	//       port: 50051
+	//     initialDelaySeconds: 10
+	//     periodSeconds: 10
+	grpc_health_v1.RegisterHealthServer(grpcServer, NewHealthServer(s.neo4jRepo, s.mongoRepo, s.logger))
 
 	// Register reflection service
 	reflection.Register(grpcServer)
 
-	log.Printf("[service.main] CRUD Service is running on %s:%s...", host, port)
-	if err := grpcServer.Serve(listener); err != nil {
-		log.Fatalf("[service.main] Failed to serve: %v", err)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		s.logger.Infof("[service.Run] Shutting down CRUD Service on %s", listener.Addr())
+		grpcServer.GracefulStop()
+	}()
+
+	s.logger.Infof("[service.Run] CRUD Service is running on %s...", listener.Addr())
+	// Serve fails with ErrServerStopped if ctx was cancelled before it started, which is still a clean stop
+	if err := grpcServer.Serve(listener); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to serve: %v", err)
+	}
+	<-stopped
+	return nil
+}
+
+// getEnvInt reads an integer environment variable, returning 0 when it is unset or invalid
+func getEnvInt(logger logging.Logger, key string) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		logger.Warnf("[service.getEnvInt] Ignoring invalid value for %s: %v", key, err)
+		return 0
+	}
+	return parsed
+}
+
+// getEnvFloat reads a floating point environment variable, returning 0 when it is unset or invalid
+func getEnvFloat(logger logging.Logger, key string) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		logger.Warnf("[service.getEnvFloat] Ignoring invalid value for %s: %v", key, err)
+		return 0
+	}
+	return parsed
+}
+
+// getEnvDuration reads a duration environment variable such as "30s", returning 0 when it is unset or invalid
+func getEnvDuration(logger logging.Logger, key string) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Warnf("[service.getEnvDuration] Ignoring invalid value for %s: %v", key, err)
+		return 0
 	}
+	return parsed
 }
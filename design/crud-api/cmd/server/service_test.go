@@ -2,19 +2,45 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log"
+	"net"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"lk/datafoundation/crud-api/db/config"
 	mongorepository "lk/datafoundation/crud-api/db/repository/mongo"
 	neo4jrepository "lk/datafoundation/crud-api/db/repository/neo4j"
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+// server is backed by the live MongoDB and Neo4j repositories, and is nil when they are not configured
 var server *Server
 
-// TestMain sets up the actual MongoDB and Neo4j repositories before running the tests
+// TestMain sets up the actual MongoDB and Neo4j repositories before running the tests.
+// Without NEO4J_URI and MONGO_URI only the tests that use fake repositories run; the others are skipped.
 func TestMain(m *testing.M) {
+	// Spans are discarded unless a test installs its own provider
+	otel.SetTracerProvider(noop.NewTracerProvider())
+
+	if os.Getenv("NEO4J_URI") == "" || os.Getenv("MONGO_URI") == "" {
+		log.Printf("NEO4J_URI or MONGO_URI is not set, skipping the database tests")
+		os.Exit(m.Run())
+	}
+
 	// Load environment variables for database configurations
 	neo4jConfig := &config.Neo4jConfig{
 		URI:      os.Getenv("NEO4J_URI"),
@@ -30,23 +56,20 @@ func TestMain(m *testing.M) {
 
 	// Initialize Neo4j repository
 	ctx := context.Background()
-	neo4jRepo, err := neo4jrepository.NewNeo4jRepository(ctx, neo4jConfig)
+	neo4jRepo, err := neo4jrepository.NewNeo4jRepository(ctx, neo4jConfig, nil)
 	if err != nil {
 		log.Fatalf("Failed to initialize Neo4j repository: %v", err)
 	}
 	defer neo4jRepo.Close(ctx)
 
 	// Initialize MongoDB repository
-	mongoRepo := mongorepository.NewMongoRepository(ctx, mongoConfig)
+	mongoRepo := mongorepository.NewMongoRepository(ctx, mongoConfig, nil)
 	if mongoRepo == nil {
 		log.Fatalf("Failed to initialize MongoDB repository")
 	}
 
 	// Create the server with the initialized repositories
-	server = &Server{
-		mongoRepo: mongoRepo,
-		neo4jRepo: neo4jRepo,
-	}
+	server = NewServer(mongoRepo, neo4jRepo)
 
 	// Run the tests
 	code := m.Run()
@@ -95,6 +118,7 @@ func TestMain(m *testing.M) {
 // TestCreateEntity tests the CreateEntity function
 // TODO: FIX BUG @zaeema-n there is a bug in the CreateEntity function
 func TestCreateEntity(t *testing.T) {
+	requireDatabases(t)
 
 	// Encode Name as *anypb.Any
 	// nameValue, err := anypb.New(&anypb.Any{Value: []byte("John Doe")})
@@ -153,3 +177,440 @@ func TestCreateEntity(t *testing.T) {
 	// 	})
 	// }
 }
+
+// requireDatabases skips the test when TestMain did not connect to MongoDB and Neo4j
+func requireDatabases(t *testing.T) {
+	t.Helper()
+	if server == nil {
+		t.Skip("NEO4J_URI and MONGO_URI are not set")
+	}
+}
+
+// newTestClient serves the test server over an in-memory listener and returns a client for it
+func newTestClient(t *testing.T) pb.CrudServiceClient {
+	requireDatabases(t)
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	pb.RegisterCrudServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create gRPC client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewCrudServiceClient(conn)
+}
+
+// newTestEntity builds an entity with the fields required by both MongoDB and Neo4j
+func newTestEntity(t *testing.T, id string, kind *pb.Kind, name string) *pb.Entity {
+	nameValue, err := anypb.New(wrapperspb.String(name))
+	assert.NoError(t, err)
+	metadataValue, err := anypb.New(wrapperspb.String("metadata of " + id))
+	assert.NoError(t, err)
+
+	return &pb.Entity{
+		Id:       id,
+		Kind:     kind,
+		Name:     &pb.TimeBasedValue{StartTime: "2025-03-20T00:00:00Z", Value: nameValue},
+		Created:  "2025-03-20T00:00:00Z",
+		Metadata: map[string]*anypb.Any{"source": metadataValue},
+	}
+}
+
+// TestStreamEntities streams 100 entities through a gRPC client and verifies their order
+func TestStreamEntities(t *testing.T) {
+	requireDatabases(t)
+	ctx := context.Background()
+	kind := &pb.Kind{Major: "StreamTest", Minor: "Record"}
+
+	for i := 1; i <= 100; i++ {
+		entity := newTestEntity(t, fmt.Sprintf("stream-%03d", i), kind, fmt.Sprintf("Stream Record %d", i))
+		_, err := server.CreateEntity(ctx, entity)
+		assert.NoError(t, err, "Error creating entity %s", entity.Id)
+	}
+
+	client := newTestClient(t)
+	streamCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamEntities(streamCtx, &pb.FilterEntitiesRequest{Kind: kind})
+	assert.NoError(t, err)
+
+	received := 0
+	for {
+		entity, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err, "Error receiving entity") {
+			break
+		}
+		received++
+		assert.Equal(t, fmt.Sprintf("stream-%03d", received), entity.Id, "Expected entities in order")
+		assert.Equal(t, "StreamTest", entity.Kind.Major)
+		assert.Contains(t, entity.Metadata, "source")
+	}
+	assert.Equal(t, 100, received, "Expected all entities to be streamed")
+}
+
+// cancellingStream records sent entities and cancels its context after the first one, like a client going away
+type cancellingStream struct {
+	pb.CrudService_StreamEntitiesServer
+	ctx    context.Context
+	cancel context.CancelFunc
+	sent   int
+}
+
+func (s *cancellingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *cancellingStream) Send(entity *pb.Entity) error {
+	s.sent++
+	s.cancel()
+	return nil
+}
+
+// TestStreamEntitiesCancelled tests that StreamEntities stops paging once the client cancels the stream
+func TestStreamEntitiesCancelled(t *testing.T) {
+	requireDatabases(t)
+	ctx := context.Background()
+	kind := &pb.Kind{Major: "StreamCancelTest", Minor: "Record"}
+
+	// Create more entities than fit in one page
+	for i := 1; i <= streamPageSize+10; i++ {
+		entity := newTestEntity(t, fmt.Sprintf("stream-cancel-%03d", i), kind, fmt.Sprintf("Stream Cancel Record %d", i))
+		_, err := server.CreateEntity(ctx, entity)
+		assert.NoError(t, err, "Error creating entity %s", entity.Id)
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream := &cancellingStream{ctx: streamCtx, cancel: cancel}
+
+	// Nothing more is read or sent once the stream is cancelled
+	err := server.StreamEntities(&pb.FilterEntitiesRequest{Kind: kind}, stream)
+	assert.ErrorIs(t, err, context.Canceled, "Expected the stream to stop with the cancellation error")
+	assert.Equal(t, 1, stream.sent, "Expected no entities to be sent after cancellation")
+}
+
+// TestStreamEntitiesNoResults verifies the stream terminates cleanly when nothing matches
+func TestStreamEntitiesNoResults(t *testing.T) {
+	client := newTestClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamEntities(ctx, &pb.FilterEntitiesRequest{
+		Kind: &pb.Kind{Major: "StreamTest"},
+		Name: "no-such-entity",
+	})
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err, "Expected the stream to end without results")
+}
+
+// TestCreateAndReadEntityAttributes verifies that attributes with several time based values round-trip intact
+func TestCreateAndReadEntityAttributes(t *testing.T) {
+	requireDatabases(t)
+	ctx := context.Background()
+	entity := newTestEntity(t, "attributes-entity-001", &pb.Kind{Major: "Organization", Minor: "Ministry"}, "Ministry of Attributes")
+
+	// Build two attributes, each holding two time based values
+	newValue := func(value string, start string, end string) *pb.TimeBasedValue {
+		anyValue, err := anypb.New(wrapperspb.String(value))
+		assert.NoError(t, err)
+		return &pb.TimeBasedValue{StartTime: start, EndTime: end, Value: anyValue}
+	}
+	entity.Attributes = map[string]*pb.TimeBasedValueList{
+		"minister": {Values: []*pb.TimeBasedValue{
+			newValue("Alice", "2020-01-01T00:00:00Z", "2022-01-01T00:00:00Z"),
+			newValue("Bob", "2022-01-01T00:00:00Z", ""),
+		}},
+		"budget": {Values: []*pb.TimeBasedValue{
+			newValue("1000000", "2020-01-01T00:00:00Z", "2021-01-01T00:00:00Z"),
+			newValue("1500000", "2021-01-01T00:00:00Z", ""),
+		}},
+	}
+
+	_, err := server.CreateEntity(ctx, entity)
+	assert.NoError(t, err, "Error creating entity with attributes")
+
+	readResp, err := server.ReadEntity(ctx, &pb.ReadEntityRequest{
+		Id:     entity.Id,
+		Output: []string{"attributes"},
+	})
+	assert.NoError(t, err, "Error reading entity attributes")
+	assert.Equal(t, 2, len(readResp.Attributes), "Expected both attributes")
+
+	for key, expected := range entity.Attributes {
+		actual, ok := readResp.Attributes[key]
+		if !assert.True(t, ok, "Expected attribute %s", key) {
+			continue
+		}
+		assert.Equal(t, len(expected.Values), len(actual.Values), "Expected all values of attribute %s", key)
+		for i := range expected.Values {
+			assert.Equal(t, expected.Values[i].StartTime, actual.Values[i].StartTime)
+			assert.Equal(t, expected.Values[i].EndTime, actual.Values[i].EndTime)
+
+			expectedValue := &wrapperspb.StringValue{}
+			actualValue := &wrapperspb.StringValue{}
+			assert.NoError(t, expected.Values[i].Value.UnmarshalTo(expectedValue))
+			assert.NoError(t, actual.Values[i].Value.UnmarshalTo(actualValue))
+			assert.Equal(t, expectedValue.Value, actualValue.Value)
+		}
+	}
+}
+
+// stringAny packs a string into an Any for metadata values
+func stringAny(t *testing.T, value string) *anypb.Any {
+	packed, err := anypb.New(wrapperspb.String(value))
+	assert.NoError(t, err)
+	return packed
+}
+
+// TestMergeMetadata checks how each merge strategy combines source and target metadata
+func TestMergeMetadata(t *testing.T) {
+	empty := &anypb.Any{TypeUrl: "type.googleapis.com/google.protobuf.StringValue"}
+	source := map[string]*anypb.Any{
+		"shared":     stringAny(t, "from source"),
+		"sourceOnly": stringAny(t, "source value"),
+		"blank":      stringAny(t, "filled by source"),
+	}
+	target := map[string]*anypb.Any{
+		"shared":     stringAny(t, "from target"),
+		"targetOnly": stringAny(t, "target value"),
+		"blank":      empty,
+	}
+
+	overwrite, err := mergeMetadata(source, target, pb.MergeStrategy_OVERWRITE)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(overwrite), "Expected keys from both entities")
+	assert.True(t, proto.Equal(target["shared"], overwrite["shared"]), "Expected the target value to win")
+	assert.True(t, proto.Equal(empty, overwrite["blank"]), "Expected the empty target value to win")
+	assert.True(t, proto.Equal(source["sourceOnly"], overwrite["sourceOnly"]), "Expected source-only keys to be added")
+
+	preserve, err := mergeMetadata(source, target, pb.MergeStrategy_PRESERVE)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(target["shared"], preserve["shared"]), "Expected the non-empty target value to be kept")
+	assert.True(t, proto.Equal(source["blank"], preserve["blank"]), "Expected the source to fill the empty target value")
+	assert.True(t, proto.Equal(target["targetOnly"], preserve["targetOnly"]))
+
+	_, err = mergeMetadata(source, target, pb.MergeStrategy_UNION)
+	assert.Error(t, err, "Expected a conflict error for keys with different values")
+
+	union, err := mergeMetadata(
+		map[string]*anypb.Any{"a": stringAny(t, "1"), "same": stringAny(t, "x")},
+		map[string]*anypb.Any{"b": stringAny(t, "2"), "same": stringAny(t, "x")},
+		pb.MergeStrategy_UNION,
+	)
+	assert.NoError(t, err, "Expected keys with equal values not to conflict")
+	assert.Equal(t, 3, len(union), "Expected the union of both maps")
+}
+
+// TestMergeEntities merges entities with each strategy and checks the stored target and the terminated source
+func TestMergeEntities(t *testing.T) {
+	requireDatabases(t)
+	ctx := context.Background()
+	kind := &pb.Kind{Major: "Organization", Minor: "Department"}
+
+	for _, strategy := range []pb.MergeStrategy{pb.MergeStrategy_OVERWRITE, pb.MergeStrategy_PRESERVE, pb.MergeStrategy_UNION} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			sourceId := "merge-source-" + strings.ToLower(strategy.String())
+			targetId := "merge-target-" + strings.ToLower(strategy.String())
+			childId := "merge-child-" + strings.ToLower(strategy.String())
+
+			source := newTestEntity(t, sourceId, kind, "Merge Source")
+			source.Metadata = map[string]*anypb.Any{"sourceOnly": stringAny(t, "from source")}
+			source.Relationships = map[string]*pb.Relationship{
+				"child": {Id: "merge-rel-" + childId, Name: "has_child", RelatedEntityId: childId, StartTime: "2025-03-20T00:00:00Z"},
+			}
+			target := newTestEntity(t, targetId, kind, "Merge Target")
+			target.Metadata = map[string]*anypb.Any{"targetOnly": stringAny(t, "from target")}
+
+			// The child has to exist before the source can link to it
+			for _, entity := range []*pb.Entity{newTestEntity(t, childId, kind, "Merge Child"), source, target} {
+				_, err := server.CreateEntity(ctx, entity)
+				assert.NoError(t, err, "Error creating entity %s", entity.Id)
+			}
+
+			merged, err := server.MergeEntities(ctx, &pb.MergeEntitiesRequest{
+				SourceId:        sourceId,
+				TargetId:        targetId,
+				Strategy:        strategy,
+				TerminateSource: true,
+			})
+			assert.NoError(t, err, "Error merging entities")
+			if !assert.NotNil(t, merged) {
+				return
+			}
+
+			// The target carries both sets of metadata and the source's relationship
+			assert.Equal(t, targetId, merged.Id)
+			assert.Contains(t, merged.Metadata, "sourceOnly", "Expected the source metadata on the target")
+			assert.Contains(t, merged.Metadata, "targetOnly", "Expected the target metadata to be kept")
+			relatedIds := []string{}
+			for _, rel := range merged.Relationships {
+				relatedIds = append(relatedIds, rel.RelatedEntityId)
+			}
+			assert.Contains(t, relatedIds, childId, "Expected the source relationship to be copied to the target")
+
+			// The source is terminated
+			readSource, err := server.ReadEntity(ctx, &pb.ReadEntityRequest{Id: sourceId})
+			assert.NoError(t, err, "Error reading the source entity")
+			assert.NotEmpty(t, readSource.Terminated, "Expected the source entity to be terminated")
+
+			// The source's outgoing relationships are closed, so only the target holds them
+			sourceRelationships, err := server.neo4jRepo.ReadRelationships(ctx, sourceId)
+			assert.NoError(t, err, "Error reading the source relationships")
+			assert.NotEmpty(t, sourceRelationships, "Expected the source relationships to be kept")
+			for _, rel := range sourceRelationships {
+				if rel["direction"] == "OUTGOING" {
+					assert.NotEmpty(t, rel["Terminated"], "Expected source relationship %v to be terminated", rel["relationshipID"])
+				}
+			}
+		})
+	}
+
+	// Conflicting keys fail a UNION merge without terminating the source
+	source := newTestEntity(t, "merge-conflict-source", kind, "Conflict Source")
+	target := newTestEntity(t, "merge-conflict-target", kind, "Conflict Target")
+	for _, entity := range []*pb.Entity{source, target} {
+		_, err := server.CreateEntity(ctx, entity)
+		assert.NoError(t, err, "Error creating entity %s", entity.Id)
+	}
+	_, err := server.MergeEntities(ctx, &pb.MergeEntitiesRequest{
+		SourceId:        source.Id,
+		TargetId:        target.Id,
+		Strategy:        pb.MergeStrategy_UNION,
+		TerminateSource: true,
+	})
+	assert.Error(t, err, "Expected a conflict on the differing 'source' metadata")
+	readSource, err := server.ReadEntity(ctx, &pb.ReadEntityRequest{Id: source.Id})
+	assert.NoError(t, err)
+	assert.Empty(t, readSource.Terminated, "Expected the source entity to stay active after a failed merge")
+}
+
+// TestDuplicateEntity copies an entity with its relationships and verifies the copy is independent of the original
+func TestDuplicateEntity(t *testing.T) {
+	requireDatabases(t)
+	ctx := context.Background()
+	kind := &pb.Kind{Major: "Organization", Minor: "Department"}
+
+	source := newTestEntity(t, "duplicate-source", kind, "Duplicate Source")
+	source.Metadata["owner"] = stringAny(t, "original owner")
+	source.Relationships = map[string]*pb.Relationship{
+		"child": {Id: "duplicate-rel-1", Name: "has_child", RelatedEntityId: "duplicate-child", StartTime: "2025-03-20T00:00:00Z"},
+	}
+	for _, entity := range []*pb.Entity{newTestEntity(t, "duplicate-child", kind, "Duplicate Child"), source} {
+		_, err := server.CreateEntity(ctx, entity)
+		assert.NoError(t, err, "Error creating entity %s", entity.Id)
+	}
+
+	duplicate, err := server.DuplicateEntity(ctx, &pb.DuplicateEntityRequest{
+		Id:                "duplicate-source",
+		NewId:             "duplicate-copy",
+		CopyRelationships: true,
+	})
+	assert.NoError(t, err, "Error duplicating the entity")
+	if !assert.NotNil(t, duplicate) {
+		return
+	}
+
+	// The copy is created now and carries the metadata and the outgoing relationship of the source
+	assert.Equal(t, "duplicate-copy", duplicate.Id)
+	assert.NotEqual(t, source.Created, duplicate.Created, "Expected the copy to be created now")
+	assert.Contains(t, duplicate.Metadata, "owner")
+	relatedIds := []string{}
+	for _, rel := range duplicate.Relationships {
+		relatedIds = append(relatedIds, rel.RelatedEntityId)
+	}
+	assert.Contains(t, relatedIds, "duplicate-child", "Expected the relationship to be copied")
+
+	// Changing the copy's metadata leaves the original untouched
+	_, err = server.UpdateEntity(ctx, &pb.UpdateEntityRequest{
+		Id: "duplicate-copy",
+		Entity: &pb.Entity{
+			Id:       "duplicate-copy",
+			Metadata: map[string]*anypb.Any{"owner": stringAny(t, "new owner")},
+		},
+	})
+	assert.NoError(t, err, "Error updating the duplicate")
+
+	original, err := server.ReadEntity(ctx, &pb.ReadEntityRequest{Id: "duplicate-source", Output: []string{"metadata"}})
+	assert.NoError(t, err, "Error reading the original entity")
+	owner := &wrapperspb.StringValue{}
+	assert.NoError(t, original.Metadata["owner"].UnmarshalTo(owner))
+	assert.Equal(t, "original owner", owner.Value, "Expected the original metadata to be unchanged")
+
+	// Without a new Id a UUID is generated
+	generated, err := server.DuplicateEntity(ctx, &pb.DuplicateEntityRequest{Id: "duplicate-source"})
+	assert.NoError(t, err, "Error duplicating the entity with a generated Id")
+	if assert.NotNil(t, generated) {
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, generated.Id)
+		assert.Empty(t, generated.Relationships, "Expected no relationships without CopyRelationships")
+	}
+}
+
+// TestReadEntityOutputFields reads one entity with every combination of the optional output fields and checks
+// that only the requested fields are filled, while kind, name and created are always returned
+func TestReadEntityOutputFields(t *testing.T) {
+	requireDatabases(t)
+	ctx := context.Background()
+	kind := &pb.Kind{Major: "Organization", Minor: "Ministry"}
+
+	child := newTestEntity(t, "output-child-001", kind, "Output Child")
+	entity := newTestEntity(t, "output-entity-001", kind, "Output Entity")
+	entity.Attributes = map[string]*pb.TimeBasedValueList{
+		"budget": {Values: []*pb.TimeBasedValue{{StartTime: "2025-03-20T00:00:00Z", Value: stringAny(t, "1000")}}},
+	}
+	entity.Relationships = map[string]*pb.Relationship{
+		"child": {Id: "output-rel-001", Name: "has_child", RelatedEntityId: child.Id, StartTime: "2025-03-20T00:00:00Z"},
+	}
+	for _, e := range []*pb.Entity{child, entity} {
+		_, err := server.CreateEntity(ctx, e)
+		assert.NoError(t, err, "Error creating entity %s", e.Id)
+	}
+
+	optional := []string{"metadata", "relationships", "attributes"}
+	for mask := 0; mask < 1<<len(optional); mask++ {
+		requested := map[string]bool{}
+		output := []string{"kind", "name", "created", "terminated"}
+		for i, field := range optional {
+			if mask&(1<<i) != 0 {
+				requested[field] = true
+				output = append(output, field)
+			}
+		}
+
+		t.Run(strings.Join(output, ","), func(t *testing.T) {
+			resp, err := server.ReadEntity(ctx, &pb.ReadEntityRequest{Id: entity.Id, Output: output})
+			assert.NoError(t, err, "Error reading entity")
+
+			assert.Equal(t, "Organization", resp.Kind.GetMajor(), "Expected the kind to always be returned")
+			assert.Equal(t, "2025-03-20T00:00:00Z", resp.Created, "Expected the created date to always be returned")
+			assert.NotNil(t, resp.Name.GetValue(), "Expected the name to always be returned")
+			assert.Empty(t, resp.Terminated, "Expected the entity not to be terminated")
+
+			assert.Equal(t, requested["metadata"], len(resp.Metadata) > 0, "Unexpected metadata presence")
+			assert.Equal(t, requested["relationships"], len(resp.Relationships) > 0, "Unexpected relationships presence")
+			assert.Equal(t, requested["attributes"], len(resp.Attributes) > 0, "Unexpected attributes presence")
+		})
+	}
+
+	// Without any output field only the basic information is returned
+	resp, err := server.ReadEntity(ctx, &pb.ReadEntityRequest{Id: entity.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, "Organization", resp.Kind.GetMajor())
+	assert.Empty(t, resp.Metadata, "Expected no metadata without an output field")
+	assert.Empty(t, resp.Relationships, "Expected no relationships without an output field")
+	assert.Empty(t, resp.Attributes, "Expected no attributes without an output field")
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+
+	neo4jrepository "lk/datafoundation/crud-api/db/repository/neo4j"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tenantHeader is the request metadata key naming the tenant a call acts for. Calls without it only see shared entities.
+const tenantHeader = "x-tenant"
+
+// tenantContext scopes the graph queries of a call to the tenant named in its metadata
+func tenantContext(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(tenantHeader)
+	switch len(values) {
+	case 0:
+		return neo4jrepository.WithTenant(ctx, ""), nil
+	case 1:
+		return neo4jrepository.WithTenant(ctx, values[0]), nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "[%s] only one %s header is allowed", fullMethod, tenantHeader)
+	}
+}
+
+// TenantInterceptor scopes each unary call to the tenant named in its x-tenant metadata
+func TenantInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := tenantContext(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// tenantServerStream overrides the context of a stream with one scoped to its tenant
+type tenantServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tenantServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// TenantStreamInterceptor scopes each streaming call to the tenant named in its x-tenant metadata
+func TenantStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := tenantContext(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &tenantServerStream{ServerStream: stream, ctx: ctx})
+}
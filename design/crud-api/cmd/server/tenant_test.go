@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	neo4jrepository "lk/datafoundation/crud-api/db/repository/neo4j"
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TestTenantInterceptor verifies that the x-tenant header scopes the handler context to that tenant
+func TestTenantInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: pb.CrudService_ReadEntity_FullMethodName}
+	tenantOf := func(ctx context.Context) (string, error) {
+		var tenant string
+		_, err := TenantInterceptor(ctx, &pb.ReadEntityRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			tenant = neo4jrepository.TenantFromContext(ctx)
+			return req, nil
+		})
+		return tenant, err
+	}
+
+	// 1. The header names the tenant
+	tenant, err := tenantOf(metadata.NewIncomingContext(context.Background(), metadata.Pairs(tenantHeader, "tenantA")))
+	assert.NoError(t, err)
+	assert.Equal(t, "tenantA", tenant)
+
+	// 2. Without the header the call only sees shared entities
+	tenant, err = tenantOf(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, tenant)
+
+	// 3. Naming several tenants is rejected
+	_, err = tenantOf(metadata.NewIncomingContext(context.Background(), metadata.Pairs(tenantHeader, "tenantA", tenantHeader, "tenantB")))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
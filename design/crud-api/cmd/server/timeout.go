@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+	"lk/datafoundation/crud-api/pkg/logging"
+
+	"google.golang.org/grpc"
+)
+
+// methodTimeoutEnvKey returns the environment variable holding the timeout of an RPC method,
+// e.g. TIMEOUT_CREATE_ENTITY for /crud.CrudService/CreateEntity
+func methodTimeoutEnvKey(fullMethod string) string {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+
+	var key strings.Builder
+	key.WriteString("TIMEOUT")
+	for i, r := range name {
+		if unicode.IsUpper(r) || i == 0 {
+			key.WriteByte('_')
+		}
+		key.WriteRune(unicode.ToUpper(r))
+	}
+	return key.String()
+}
+
+// methodTimeoutsFromEnv reads the timeout of every unary CrudService method from its TIMEOUT_* variable.
+// Methods without a valid timeout are left out.
+func methodTimeoutsFromEnv(logger logging.Logger) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration)
+	for _, method := range pb.CrudService_ServiceDesc.Methods {
+		fullMethod := "/" + pb.CrudService_ServiceDesc.ServiceName + "/" + method.MethodName
+		if timeout := getEnvDuration(logger, methodTimeoutEnvKey(fullMethod)); timeout > 0 {
+			timeouts[fullMethod] = timeout
+		}
+	}
+	return timeouts
+}
+
+// NewTimeoutInterceptor bounds each unary call by the timeout configured for its full method name.
+// Methods without a timeout run with the incoming context unchanged. A client deadline that is already
+// tighter than the configured timeout is kept and logged.
+func NewTimeoutInterceptor(timeouts map[string]time.Duration, logger logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		timeout, ok := timeouts[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := time.Until(deadline); remaining < timeout {
+				logger.Warnf("[server.TimeoutInterceptor] Client deadline for %s (%s) is tighter than the configured timeout %s", info.FullMethod, remaining.Round(time.Millisecond), timeout)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+	"lk/datafoundation/crud-api/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// TestMethodTimeoutEnvKey verifies the environment variable names derived from RPC method names
+func TestMethodTimeoutEnvKey(t *testing.T) {
+	assert.Equal(t, "TIMEOUT_CREATE_ENTITY", methodTimeoutEnvKey(pb.CrudService_CreateEntity_FullMethodName))
+	assert.Equal(t, "TIMEOUT_READ_ENTITY", methodTimeoutEnvKey(pb.CrudService_ReadEntity_FullMethodName))
+}
+
+// TestMethodTimeoutsFromEnv verifies that only methods with a valid timeout are configured
+func TestMethodTimeoutsFromEnv(t *testing.T) {
+	t.Setenv("TIMEOUT_CREATE_ENTITY", "5s")
+	t.Setenv("TIMEOUT_READ_ENTITY", "not-a-duration")
+
+	timeouts := methodTimeoutsFromEnv(logging.New(&bytes.Buffer{}, logging.LevelInfo))
+	assert.Equal(t, map[string]time.Duration{pb.CrudService_CreateEntity_FullMethodName: 5 * time.Second}, timeouts)
+}
+
+// TestTimeoutInterceptorCancelsSlowHandler verifies that a handler running past the configured timeout sees its context cancelled
+func TestTimeoutInterceptorCancelsSlowHandler(t *testing.T) {
+	interceptor := NewTimeoutInterceptor(map[string]time.Duration{
+		pb.CrudService_CreateEntity_FullMethodName: 20 * time.Millisecond,
+	}, logging.New(&bytes.Buffer{}, logging.LevelInfo))
+
+	slowHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return req, nil
+		}
+	}
+
+	start := time.Now()
+	_, err := interceptor(context.Background(), &pb.Entity{}, &grpc.UnaryServerInfo{FullMethod: pb.CrudService_CreateEntity_FullMethodName}, slowHandler)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected the handler context to be cancelled")
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "Expected the handler to stop at the timeout")
+
+	// Methods without a timeout keep the incoming context
+	_, err = interceptor(context.Background(), &pb.ReadEntityRequest{}, &grpc.UnaryServerInfo{FullMethod: pb.CrudService_ReadEntity_FullMethodName},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, hasDeadline := ctx.Deadline()
+			assert.False(t, hasDeadline, "Expected no deadline for a method without a timeout")
+			return req, nil
+		})
+	assert.NoError(t, err)
+}
+
+// TestTimeoutInterceptorWarnsOnTighterDeadline verifies that a client deadline shorter than the configured timeout is logged
+func TestTimeoutInterceptorWarnsOnTighterDeadline(t *testing.T) {
+	var output bytes.Buffer
+	interceptor := NewTimeoutInterceptor(map[string]time.Duration{
+		pb.CrudService_CreateEntity_FullMethodName: time.Minute,
+	}, logging.New(&output, logging.LevelInfo))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := interceptor(ctx, &pb.Entity{}, &grpc.UnaryServerInfo{FullMethod: pb.CrudService_CreateEntity_FullMethodName},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil })
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "level=WARN")
+	assert.Contains(t, output.String(), "tighter than the configured timeout")
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"lk/datafoundation/crud-api/pkg/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// tlsServerOption builds the gRPC transport credentials from TLS_CERT_FILE and TLS_KEY_FILE.
+// When CA_CERT_FILE is also set, clients must present a certificate signed by that CA (mutual TLS).
+// It returns nil when the certificate variables are absent, so the server keeps listening insecurely.
+func tlsServerOption(logger logging.Logger) (grpc.ServerOption, error) {
+	certFile := os.Getenv("TLS_CERT_FILE")
+	keyFile := os.Getenv("TLS_KEY_FILE")
+	if certFile == "" && keyFile == "" {
+		logger.Infof("[service.tlsServerOption] TLS_CERT_FILE and TLS_KEY_FILE not set, serving without TLS")
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("both TLS_CERT_FILE and TLS_KEY_FILE must be set to enable TLS")
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate pair: %v", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if caFile := os.Getenv("CA_CERT_FILE"); caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %v", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("failed to parse CA certificate from %s", caFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		logger.Infof("[service.tlsServerOption] Mutual TLS enabled with CA %s", caFile)
+	}
+
+	logger.Infof("[service.tlsServerOption] TLS enabled with certificate %s", certFile)
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+	"lk/datafoundation/crud-api/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// writeSelfSignedCertificate writes a self-signed certificate for localhost and its key to dir
+func writeSelfSignedCertificate(t *testing.T, dir string) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile, certificate
+}
+
+// TestTLSServerOptionDisabled tests that the server stays insecure when no certificate is configured
+func TestTLSServerOptionDisabled(t *testing.T) {
+	t.Setenv("TLS_CERT_FILE", "")
+	t.Setenv("TLS_KEY_FILE", "")
+
+	option, err := tlsServerOption(logging.New(&bytes.Buffer{}, logging.LevelInfo))
+	assert.NoError(t, err)
+	assert.Nil(t, option, "Expected no TLS option without certificate files")
+
+	t.Setenv("TLS_CERT_FILE", "cert.pem")
+	_, err = tlsServerOption(logging.New(&bytes.Buffer{}, logging.LevelInfo))
+	assert.Error(t, err, "Expected an error when only the certificate file is set")
+}
+
+// TestCreateEntityOverTLS tests a CreateEntity call over a TLS connection with a self-signed certificate
+func TestCreateEntityOverTLS(t *testing.T) {
+	requireDatabases(t)
+	certFile, keyFile, certificate := writeSelfSignedCertificate(t, t.TempDir())
+	t.Setenv("TLS_CERT_FILE", certFile)
+	t.Setenv("TLS_KEY_FILE", keyFile)
+	t.Setenv("CA_CERT_FILE", "")
+
+	option, err := tlsServerOption(logging.New(&bytes.Buffer{}, logging.LevelInfo))
+	assert.NoError(t, err)
+	if !assert.NotNil(t, option, "Expected a TLS option with certificate files") {
+		return
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(option)
+	pb.RegisterCrudServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(certificate)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: roots, ServerName: "localhost"})),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := pb.NewCrudServiceClient(conn)
+
+	created, err := client.CreateEntity(ctx, newTestEntity(t, "tls-entity-1", &pb.Kind{Major: "TLSTest", Minor: "Record"}, "TLS Record"))
+	assert.NoError(t, err, "Expected CreateEntity to succeed over TLS")
+	if created != nil {
+		assert.Equal(t, "tls-entity-1", created.Id)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// TestCreateEntityTracing verifies that CreateEntity traces its database operations:
+// 1. Installs a tracer provider that records finished spans
+// 2. Creates an entity with metadata under a parent span
+// 3. Confirms at least one neo4j and one mongo span were recorded as children of the parent span
+func TestCreateEntityTracing(t *testing.T) {
+	requireDatabases(t)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "test.CreateEntity")
+	nameValue, _ := anypb.New(wrapperspb.String("Traced Entity"))
+	entity := &pb.Entity{
+		Id:       "tracing-entity-1",
+		Kind:     &pb.Kind{Major: "Person", Minor: "Employee"},
+		Created:  "2025-01-01T00:00:00Z",
+		Name:     &pb.TimeBasedValue{StartTime: "2025-01-01T00:00:00Z", Value: nameValue},
+		Metadata: map[string]*anypb.Any{"source": nameValue},
+	}
+	_, err := server.CreateEntity(ctx, entity)
+	parent.End()
+	assert.NoError(t, err)
+
+	var neo4jSpans, mongoSpans int
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(span.Name(), "neo4j."):
+			neo4jSpans++
+		case strings.HasPrefix(span.Name(), "mongo."):
+			mongoSpans++
+		}
+	}
+	assert.GreaterOrEqual(t, neo4jSpans, 1, "Expected a neo4j child span")
+	assert.GreaterOrEqual(t, mongoSpans, 1, "Expected a mongo child span")
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxEntityIdLength is the longest entity Id accepted by the server
+const maxEntityIdLength = 256
+
+// entityIdPattern lists the characters allowed in an entity Id
+var entityIdPattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// validateEntityId checks that an entity Id is non-empty, bounded in length and uses only allowed characters
+func validateEntityId(id string) error {
+	if id == "" {
+		return fmt.Errorf("entity Id cannot be empty")
+	}
+	if len(id) > maxEntityIdLength {
+		return fmt.Errorf("entity Id must be at most %d characters, got %d", maxEntityIdLength, len(id))
+	}
+	if !entityIdPattern.MatchString(id) {
+		return fmt.Errorf("entity Id %q may only contain letters, digits, '_', '-' and '.'", id)
+	}
+	return nil
+}
+
+// relationshipTypePattern lists the relationship types that may be requested by name. Neo4j cannot take a relationship
+// type as a query parameter, so anything else could change the query it is written into.
+var relationshipTypePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateRelationshipType checks that a requested relationship type is a plain identifier
+func validateRelationshipType(name string) error {
+	if !relationshipTypePattern.MatchString(name) {
+		return fmt.Errorf("invalid relationship type %q: only letters, digits and '_' are allowed", name)
+	}
+	return nil
+}
+
+// ValidationInterceptor rejects CreateEntity, UpdateEntity, MergeEntities and DuplicateEntity requests with an invalid entity Id
+// before they reach the handler, returning codes.InvalidArgument.
+func ValidationInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var err error
+	switch msg := req.(type) {
+	case *pb.Entity:
+		err = validateEntityId(msg.Id)
+	case *pb.UpdateEntityRequest:
+		err = validateEntityId(msg.Id)
+		if err == nil && msg.Entity != nil && msg.Entity.Id != "" {
+			err = validateEntityId(msg.Entity.Id)
+		}
+	case *pb.MergeEntitiesRequest:
+		err = validateEntityId(msg.SourceId)
+		if err == nil {
+			err = validateEntityId(msg.TargetId)
+		}
+	case *pb.DuplicateEntityRequest:
+		err = validateEntityId(msg.Id)
+		if err == nil && msg.NewId != "" {
+			err = validateEntityId(msg.NewId)
+		}
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "[%s] %v", info.FullMethod, err)
+	}
+	return handler(ctx, req)
+}
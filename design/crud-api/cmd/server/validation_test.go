@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestValidationInterceptor tests that the interceptor only passes requests with a valid entity Id to the handler
+func TestValidationInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/crud.CrudService/CreateEntity"}
+
+	tests := []struct {
+		name  string
+		req   interface{}
+		valid bool
+	}{
+		{"valid create", &pb.Entity{Id: "entity-1.v2_a"}, true},
+		{"valid update", &pb.UpdateEntityRequest{Id: "entity-1", Entity: &pb.Entity{Id: "entity-1"}}, true},
+		{"update without entity Id", &pb.UpdateEntityRequest{Id: "entity-1", Entity: &pb.Entity{}}, true},
+		{"other request type", &pb.EntityId{Id: ""}, true},
+		{"empty create Id", &pb.Entity{Id: ""}, false},
+		{"empty update Id", &pb.UpdateEntityRequest{Id: ""}, false},
+		{"too long Id", &pb.Entity{Id: strings.Repeat("a", maxEntityIdLength+1)}, false},
+		{"invalid characters", &pb.Entity{Id: "entity/1"}, false},
+		{"whitespace", &pb.Entity{Id: "entity 1"}, false},
+		{"invalid nested entity Id", &pb.UpdateEntityRequest{Id: "entity-1", Entity: &pb.Entity{Id: "entity$1"}}, false},
+		{"duplicate with generated Id", &pb.DuplicateEntityRequest{Id: "entity-1"}, true},
+		{"invalid duplicate new Id", &pb.DuplicateEntityRequest{Id: "entity-1", NewId: "copy 1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return req, nil
+			}
+
+			resp, err := ValidationInterceptor(context.Background(), tt.req, info, handler)
+			if tt.valid {
+				assert.Nil(t, err, "Expected a valid request to pass")
+				assert.True(t, called, "Expected the handler to be called")
+				assert.Equal(t, tt.req, resp, "Expected the handler response to be returned")
+				return
+			}
+
+			assert.False(t, called, "Expected the handler not to be called")
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "Expected an InvalidArgument status")
+		})
+	}
+
+	// The maximum length is accepted
+	_, err := ValidationInterceptor(context.Background(), &pb.Entity{Id: strings.Repeat("a", maxEntityIdLength)}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil })
+	assert.Nil(t, err, "Expected an Id of the maximum length to pass")
+}
@@ -1,13 +1,44 @@
 package config
 
+import "time"
+
 type MongoConfig struct {
 	URI        string `env:"MONGO_URI"`
 	DBName     string `env:"MONGO_DB_NAME"`
 	Collection string `env:"MONGO_COLLECTION"`
+
+	// EntityTTL enables expiry of entity documents this long after creation, zero keeps them indefinitely
+	EntityTTL time.Duration `env:"MONGO_ENTITY_TTL"`
 }
 
 type Neo4jConfig struct {
 	URI      string `env:"NEO4J_URI"`
 	Username string `env:"NEO4J_USER"`
 	Password string `env:"NEO4J_PASSWORD"`
+
+	// Connection pool settings, zero values fall back to the repository defaults
+	MaxConnectionPoolSize        int           `env:"NEO4J_MAX_CONNECTION_POOL_SIZE"`
+	MaxConnectionLifetime        time.Duration `env:"NEO4J_MAX_CONNECTION_LIFETIME"`
+	ConnectionAcquisitionTimeout time.Duration `env:"NEO4J_CONNECTION_ACQUISITION_TIMEOUT"`
+	SocketConnectTimeout         time.Duration `env:"NEO4J_SOCKET_CONNECT_TIMEOUT"`
+	MaxConcurrentSessions        int           `env:"NEO4J_MAX_CONCURRENT_SESSIONS"`
+
+	// QueryTimeout bounds every query run by the repository, zero disables the timeout
+	QueryTimeout time.Duration `env:"NEO4J_QUERY_TIMEOUT"`
+
+	// MaxNeighborhoodDepth caps the depth accepted by ReadNeighborhood, zero falls back to the repository default
+	MaxNeighborhoodDepth int `env:"NEO4J_MAX_NEIGHBORHOOD_DEPTH"`
+
+	// TenantIsolationMode selects how entities of different tenants are kept apart, empty means TenantIsolationLabel
+	TenantIsolationMode TenantIsolationMode `env:"NEO4J_TENANT_ISOLATION_MODE"`
 }
+
+// TenantIsolationMode is the strategy used to separate the graph data of tenants
+type TenantIsolationMode string
+
+const (
+	// TenantIsolationLabel prefixes node labels with the tenant, e.g. acme_Person
+	TenantIsolationLabel TenantIsolationMode = "LABEL"
+	// TenantIsolationDatabase keeps each tenant in its own Neo4j database
+	TenantIsolationDatabase TenantIsolationMode = "DATABASE"
+)
@@ -0,0 +1,137 @@
+package mongorepository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// HandleAttributes stores the time based attributes of an entity.
+// Each attribute key replaces the stored list for that key, other stored attributes are kept.
+func (repo *MongoRepository) HandleAttributes(ctx context.Context, entityId string, entity *pb.Entity) error {
+	// Skip operations if no attributes are provided
+	if entity == nil || len(entity.GetAttributes()) == 0 {
+		return nil
+	}
+
+	updates := bson.M{}
+	for key, valueList := range entity.GetAttributes() {
+		if key == "" || strings.ContainsAny(key, ".$") {
+			return fmt.Errorf("invalid attribute key %q: keys cannot be empty or contain '.' or '$'", key)
+		}
+		updates["attributes."+key] = valueList
+	}
+
+	ctx, op := repo.startOperation(ctx, "HandleAttributes", "update", entityId)
+	_, err := repo.collection().UpdateOne(ctx, bson.M{"_id": entityId}, bson.M{"$set": updates}, options.Update().SetUpsert(true))
+	op.end(err)
+	return err
+}
+
+// GetAttributes returns the attributes stored for an entity.
+// When asOf is non-empty, each attribute only keeps the value whose time window contains asOf
+// and attributes without such a value are omitted.
+func (repo *MongoRepository) GetAttributes(ctx context.Context, entityId string, asOf string) (map[string]*pb.TimeBasedValueList, error) {
+	attributes := make(map[string]*pb.TimeBasedValueList)
+
+	entity, err := repo.ReadEntity(ctx, entityId)
+	if err != nil {
+		repo.logger.Warnf("Error retrieving attributes for entity %s: %v", entityId, err)
+		return attributes, err
+	}
+
+	if entity.Attributes == nil {
+		return attributes, nil
+	}
+
+	if asOf == "" {
+		return entity.Attributes, nil
+	}
+
+	for key, valueList := range entity.Attributes {
+		if valueList == nil {
+			continue
+		}
+		value, err := valueAt(valueList.Values, asOf)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			attributes[key] = &pb.TimeBasedValueList{Values: []*pb.TimeBasedValue{value}}
+		}
+	}
+
+	return attributes, nil
+}
+
+// GetAttributeAtTime returns the value of one attribute of an entity whose time window contains timestamp.
+// It returns nil without an error when the attribute has no value at that time.
+func (repo *MongoRepository) GetAttributeAtTime(ctx context.Context, entityID, attributeName, timestamp string) (*pb.TimeBasedValue, error) {
+	entity, err := repo.ReadEntity(ctx, entityID)
+	if err != nil {
+		repo.logger.Warnf("Error retrieving attribute %s for entity %s: %v", attributeName, entityID, err)
+		return nil, err
+	}
+
+	valueList, ok := entity.Attributes[attributeName]
+	if !ok || valueList == nil {
+		return nil, nil
+	}
+
+	return valueAt(valueList.Values, timestamp)
+}
+
+// valueAt returns the time based value whose window contains ts, or nil if there is none.
+// A value is valid from its StartTime (inclusive) until its EndTime (exclusive); an empty
+// EndTime means the value is still valid.
+func valueAt(values []*pb.TimeBasedValue, ts string) (*pb.TimeBasedValue, error) {
+	at, err := parseTimestamp(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *pb.TimeBasedValue
+	var matchStart time.Time
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		start, err := parseTimestamp(value.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		if start.After(at) {
+			continue
+		}
+		if value.EndTime != "" {
+			end, err := parseTimestamp(value.EndTime)
+			if err != nil {
+				return nil, err
+			}
+			if !end.After(at) {
+				continue
+			}
+		}
+		// Overlapping windows should not happen, prefer the most recently started value
+		if match == nil || start.After(matchStart) {
+			match = value
+			matchStart = start
+		}
+	}
+
+	return match, nil
+}
+
+// parseTimestamp parses an RFC3339 timestamp or a plain date
+func parseTimestamp(ts string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, ts); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", ts)
+}
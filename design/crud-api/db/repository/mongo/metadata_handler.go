@@ -2,16 +2,26 @@ package mongorepository
 
 import (
 	"context"
-	"log"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrMetadataKeyNotFound is returned by GetMetadataField when the entity has no value for the key
+var ErrMetadataKeyNotFound = errors.New("metadata key not found")
+
 // Add this function to handle metadata operations
 func (repo *MongoRepository) HandleMetadata(ctx context.Context, entityId string, entity *pb.Entity) error {
 	// Skip operations if no metadata is provided
@@ -20,12 +30,12 @@ func (repo *MongoRepository) HandleMetadata(ctx context.Context, entityId string
 	}
 
 	// Check if entity exists
-	existingEntity, err := repo.ReadEntity(ctx, entityId)
-	if err != nil && err != mongo.ErrNoDocuments {
+	exists, err := repo.EntityExists(ctx, entityId)
+	if err != nil {
 		return err
 	}
 
-	if existingEntity == nil {
+	if !exists {
 		// Create new entity with all fields including metadata
 		newEntity := &pb.Entity{
 			Id:            entityId,
@@ -39,10 +49,10 @@ func (repo *MongoRepository) HandleMetadata(ctx context.Context, entityId string
 		}
 		_, err = repo.CreateEntity(ctx, newEntity)
 	} else {
-		// Update existing entity's metadata
+		// Update existing entity's metadata, keeping the previous state as a version
 		// TODO: Should we choose _id for placing our id or should we use id field separately and use that.
 		// Because then it is going to be reading or deleting or whatever by filtering using an attribute not the id of the object.
-		_, err = repo.UpdateEntity(ctx, existingEntity.Id, bson.M{"metadata": entity.GetMetadata()})
+		_, err = repo.UpdateMetadata(ctx, entityId, entity.GetMetadata())
 	}
 
 	return err
@@ -54,7 +64,7 @@ func (repo *MongoRepository) GetMetadata(ctx context.Context, entityId string) (
 	entity, err := repo.ReadEntity(ctx, entityId)
 	if err != nil {
 		// Log error and return empty metadata map
-		log.Printf("Error retrieving metadata for entity %s: %v", entityId, err)
+		repo.logger.Warnf("Error retrieving metadata for entity %s: %v", entityId, err)
 		metadata := make(map[string]*anypb.Any)
 		return metadata, nil
 	}
@@ -68,3 +78,212 @@ func (repo *MongoRepository) GetMetadata(ctx context.Context, entityId string) (
 	// Return the original protobuf Any metadata
 	return entity.Metadata, nil
 }
+
+// GetMetadataField returns a single metadata value of an entity, projecting only that key from the document.
+// It returns mongo.ErrNoDocuments when the entity does not exist and ErrMetadataKeyNotFound when the key is absent.
+func (repo *MongoRepository) GetMetadataField(ctx context.Context, entityId string, key string) (*anypb.Any, error) {
+	if key == "" || strings.ContainsAny(key, ".$") {
+		return nil, fmt.Errorf("invalid metadata key %q: keys cannot be empty or contain '.' or '$'", key)
+	}
+
+	var doc entityDocument
+	projection := options.FindOne().SetProjection(bson.M{"metadata." + key: 1})
+	ctx, op := repo.startOperation(ctx, "GetMetadataField", "find", entityId)
+	err := repo.collection().FindOne(ctx, bson.M{"_id": entityId}, projection).Decode(&doc)
+	op.end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := doc.Metadata[key]
+	if !ok || value == nil {
+		return nil, fmt.Errorf("%w: %s for entity %s", ErrMetadataKeyNotFound, key, entityId)
+	}
+	return value, nil
+}
+
+// exportedMetadata is one line of the NDJSON written by ExportAll
+type exportedMetadata struct {
+	ID       string                     `json:"id"`
+	Metadata map[string]json.RawMessage `json:"metadata"`
+}
+
+// ExportAll writes the metadata of every entity to w as newline-delimited JSON, one {"id", "metadata"} object per
+// entity ordered by Id. Metadata values are unpacked from their Any wrappers and written in their protobuf JSON form.
+// Documents are read through a cursor so the collection is never held in memory. It returns the number of entities written.
+func (repo *MongoRepository) ExportAll(ctx context.Context, w io.Writer) (int, error) {
+	findOptions := options.Find().
+		SetProjection(bson.M{"metadata": 1}).
+		SetSort(bson.M{"_id": 1})
+
+	ctx, op := repo.startOperation(ctx, "ExportAll", "find", "")
+	cursor, err := repo.collection().Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		op.end(err)
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	encoder := json.NewEncoder(w)
+	count := 0
+	for cursor.Next(ctx) {
+		var doc entityDocument
+		if err = cursor.Decode(&doc); err != nil {
+			break
+		}
+
+		line := exportedMetadata{ID: doc.ID, Metadata: make(map[string]json.RawMessage, len(doc.Metadata))}
+		for key, value := range doc.Metadata {
+			if line.Metadata[key], err = decodeMetadataValue(value); err != nil {
+				err = fmt.Errorf("error decoding metadata %s of entity %s: %v", key, doc.ID, err)
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+
+		if err = encoder.Encode(line); err != nil {
+			break
+		}
+		count++
+	}
+	if err == nil {
+		err = cursor.Err()
+	}
+	op.end(err)
+	if err != nil {
+		repo.logger.Errorf("[mongodb_client.ExportAll] export stopped after %d entities: %v", count, err)
+		return count, err
+	}
+
+	return count, nil
+}
+
+// decodeMetadataValue unpacks a metadata value and renders the wrapped message as JSON
+func decodeMetadataValue(value *anypb.Any) (json.RawMessage, error) {
+	if value == nil {
+		return json.RawMessage("null"), nil
+	}
+	message, err := value.UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
+
+// MetadataStatsCacheTTL is how long GetMetadataStats reuses a computed result
+const MetadataStatsCacheTTL = 5 * time.Minute
+
+// mostCommonKeysLimit is the number of keys reported in MetadataStats.MostCommonKeys
+const mostCommonKeysLimit = 10
+
+// MetadataStats summarises the metadata of the entities of a kind
+type MetadataStats struct {
+	TotalEntities        int64
+	EntitiesWithMetadata int64
+	// AverageMetadataKeyCount is averaged over all entities, counting those without metadata as zero
+	AverageMetadataKeyCount float64
+	// MostCommonKeys lists up to 10 keys, most frequent first, ties broken alphabetically
+	MostCommonKeys []string
+}
+
+// cachedMetadataStats is a GetMetadataStats result with the time it stops being reused
+type cachedMetadataStats struct {
+	stats   MetadataStats
+	expires time.Time
+}
+
+// GetMetadataStats returns statistics on the metadata of the entities of majorKind, or of every entity when it is empty.
+// Results are cached per kind for MetadataStatsCacheTTL, so recent writes may not be reflected.
+func (repo *MongoRepository) GetMetadataStats(ctx context.Context, majorKind string) (*MetadataStats, error) {
+	if cached, ok := repo.metadataStats.Load(majorKind); ok {
+		entry := cached.(cachedMetadataStats)
+		if time.Now().Before(entry.expires) {
+			return copyMetadataStats(entry.stats), nil
+		}
+	}
+
+	var pipeline mongo.Pipeline
+	if majorKind != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"kind.major": majorKind}}})
+	}
+	// Each document is reduced to its list of metadata keys, then counted in one pass and unwound per key in another
+	pipeline = append(pipeline,
+		bson.D{{Key: "$project", Value: bson.M{
+			"keys": bson.M{"$map": bson.M{
+				"input": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$metadata", bson.M{}}}},
+				"as":    "field",
+				"in":    "$$field.k",
+			}},
+		}}},
+		bson.D{{Key: "$facet", Value: bson.M{
+			"totals": bson.A{
+				bson.M{"$project": bson.M{"keyCount": bson.M{"$size": "$keys"}}},
+				bson.M{"$group": bson.M{
+					"_id":                  nil,
+					"totalEntities":        bson.M{"$sum": 1},
+					"entitiesWithMetadata": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$keyCount", 0}}, 1, 0}}},
+					"averageKeyCount":      bson.M{"$avg": "$keyCount"},
+				}},
+			},
+			"keys": bson.A{
+				bson.M{"$unwind": "$keys"},
+				bson.M{"$group": bson.M{"_id": "$keys", "count": bson.M{"$sum": 1}}},
+				bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$limit": mostCommonKeysLimit},
+			},
+		}}},
+	)
+
+	ctx, op := repo.startOperation(ctx, "GetMetadataStats", "aggregate", "")
+	cursor, err := repo.collection().Aggregate(ctx, pipeline)
+	if err != nil {
+		op.end(err)
+		return nil, fmt.Errorf("error aggregating metadata stats: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Totals []struct {
+			TotalEntities        int64   `bson:"totalEntities"`
+			EntitiesWithMetadata int64   `bson:"entitiesWithMetadata"`
+			AverageKeyCount      float64 `bson:"averageKeyCount"`
+		} `bson:"totals"`
+		Keys []struct {
+			Key string `bson:"_id"`
+		} `bson:"keys"`
+	}
+	err = cursor.All(ctx, &results)
+	op.end(err)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding metadata stats: %v", err)
+	}
+
+	stats := MetadataStats{MostCommonKeys: []string{}}
+	if len(results) > 0 {
+		// No totals are returned when the kind has no entities
+		if len(results[0].Totals) > 0 {
+			totals := results[0].Totals[0]
+			stats.TotalEntities = totals.TotalEntities
+			stats.EntitiesWithMetadata = totals.EntitiesWithMetadata
+			stats.AverageMetadataKeyCount = totals.AverageKeyCount
+		}
+		for _, key := range results[0].Keys {
+			stats.MostCommonKeys = append(stats.MostCommonKeys, key.Key)
+		}
+	}
+
+	repo.metadataStats.Store(majorKind, cachedMetadataStats{stats: stats, expires: time.Now().Add(MetadataStatsCacheTTL)})
+	return copyMetadataStats(stats), nil
+}
+
+// copyMetadataStats returns a copy of stats that callers can modify without affecting the cache
+func copyMetadataStats(stats MetadataStats) *MetadataStats {
+	stats.MostCommonKeys = append([]string{}, stats.MostCommonKeys...)
+	return &stats
+}
@@ -2,8 +2,13 @@ package mongorepository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"lk/datafoundation/crud-api/db/config"
+	"lk/datafoundation/crud-api/pkg/logging"
 	"log"
+	"sync"
+	"time"
 
 	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
 
@@ -14,8 +19,33 @@ import (
 )
 
 type MongoRepository struct {
-	client *mongo.Client
-	config *config.MongoConfig
+	client  *mongo.Client
+	config  *config.MongoConfig
+	metrics MetricsRecorder
+	logger  logging.Logger
+
+	// metadataStats caches GetMetadataStats results by major kind
+	metadataStats sync.Map
+}
+
+// DocumentRepository is the set of document operations used by the CRUD server.
+// MongoRepository implements it, so tests can substitute an in-memory fake.
+type DocumentRepository interface {
+	Ping(ctx context.Context) error
+
+	HandleMetadata(ctx context.Context, entityId string, entity *pb.Entity) error
+	GetMetadata(ctx context.Context, entityId string) (map[string]*anypb.Any, error)
+	UpdateMetadata(ctx context.Context, id string, metadata map[string]*anypb.Any) (*mongo.UpdateResult, error)
+	HandleAttributes(ctx context.Context, entityId string, entity *pb.Entity) error
+	GetAttributes(ctx context.Context, entityId string, asOf string) (map[string]*pb.TimeBasedValueList, error)
+	DeleteEntity(ctx context.Context, id string) (*mongo.DeleteResult, error)
+}
+
+var _ DocumentRepository = (*MongoRepository)(nil)
+
+// MetricsRecorder receives one observation per collection operation, labeled by repository method
+type MetricsRecorder interface {
+	ObserveOperation(operation string, duration time.Duration, err error)
 }
 
 // A custom wrapper struct for Entity to use MongoDB's _id field
@@ -28,15 +58,50 @@ type entityDocument struct {
 	Name          *pb.TimeBasedValue                `bson:"name,omitempty"`
 	Attributes    map[string]*pb.TimeBasedValueList `bson:"attributes,omitempty"`
 	Relationships map[string]*pb.Relationship       `bson:"relationships,omitempty"`
+	Versions      []entityVersion                   `bson:"versions,omitempty"`
+	CreatedAt     time.Time                         `bson:"created_at,omitempty"`
+}
+
+// entityVersion is a snapshot of an entity's metadata kept in the versions array
+type entityVersion struct {
+	VersionNumber int                   `bson:"version_number"`
+	UpdatedAt     time.Time             `bson:"updated_at"`
+	Metadata      map[string]*anypb.Any `bson:"metadata,omitempty"`
+}
+
+// VersionSummary describes a stored version of an entity without its metadata
+type VersionSummary struct {
+	VersionNumber int
+	UpdatedAt     time.Time
 }
 
 // Convert protobuf Entity to MongoDB document
 func toDocument(entity *pb.Entity) interface{} {
-	return bson.M{
-		"_id":      entity.Id,
-		"metadata": entity.Metadata,
+	doc := bson.M{
+		"_id":        entity.Id,
+		"metadata":   entity.Metadata,
+		"created_at": time.Now().UTC(),
+		// The creation is kept as the first version of the entity
+		"versions": bson.A{
+			bson.M{
+				"version_number": 0,
+				"updated_at":     time.Now().UTC(),
+				"metadata":       entity.Metadata,
+			},
+		},
 		// Map other entity fields as needed
 	}
+	if len(entity.Attributes) > 0 {
+		doc["attributes"] = entity.Attributes
+	}
+	// Kind and created are stored so documents can be listed by kind without Neo4j
+	if entity.Kind != nil {
+		doc["kind"] = entity.Kind
+	}
+	if entity.Created != "" {
+		doc["created"] = entity.Created
+	}
+	return doc
 }
 
 // Convert MongoDB document to protobuf Entity
@@ -53,16 +118,91 @@ func fromDocument(data *entityDocument) *pb.Entity {
 	}
 }
 
-// NewMongoRepository initializes a MongoDB client
-func NewMongoRepository(ctx context.Context, config *config.MongoConfig) *MongoRepository {
+// NewMongoRepository initializes a MongoDB client. A nil logger writes to stderr at the INFO level.
+func NewMongoRepository(ctx context.Context, config *config.MongoConfig, logger logging.Logger) *MongoRepository {
+	if logger == nil {
+		logger = logging.Default()
+	}
 	clientOptions := options.Client().ApplyURI(config.URI)
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		log.Fatal(err)
 	}
-	return &MongoRepository{
+	repo := &MongoRepository{
 		client: client,
 		config: config,
+		logger: logger,
+	}
+
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		logger.Errorf("[mongodb_client.NewMongoRepository] failed to ensure indexes: %v", err)
+	}
+	return repo
+}
+
+// ttlIndexName is the name of the TTL index on created_at
+const ttlIndexName = "created_at_ttl"
+
+// EnsureIndexes creates the indexes used by the entity collection.
+// The entity Id is stored as _id, which MongoDB always indexes as unique, so duplicate inserts fail with a duplicate key error.
+// Creating an index that already exists with the same definition succeeds, so it is safe to call on every startup.
+// A compound index on the kind fields serves ListEntityIDs, and a TTL index on created_at is created when config.EntityTTL is set.
+// When the TTL index exists with another expiry, e.g. after MONGO_ENTITY_TTL changed, its expiry is updated with collMod.
+func (repo *MongoRepository) EnsureIndexes(ctx context.Context) error {
+	kindIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "kind.major", Value: 1}, {Key: "kind.minor", Value: 1}},
+		Options: options.Index().SetName("kind"),
+	}
+	if _, err := repo.collection().Indexes().CreateOne(ctx, kindIndex); err != nil {
+		return fmt.Errorf("failed to create kind index: %w", err)
+	}
+
+	if repo.config.EntityTTL <= 0 {
+		return nil
+	}
+
+	expireAfterSeconds := int32(repo.config.EntityTTL.Seconds())
+	ttlIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetName(ttlIndexName).SetExpireAfterSeconds(expireAfterSeconds),
+	}
+	_, err := repo.collection().Indexes().CreateOne(ctx, ttlIndex)
+	if isIndexOptionsConflict(err) {
+		err = repo.collection().Database().RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: repo.config.Collection},
+			{Key: "index", Value: bson.D{{Key: "name", Value: ttlIndexName}, {Key: "expireAfterSeconds", Value: expireAfterSeconds}}},
+		}).Err()
+		if err != nil {
+			return fmt.Errorf("failed to update the expiry of the created_at TTL index: %w", err)
+		}
+		repo.logger.Infof("[mongodb_client.EnsureIndexes] updated the created_at TTL index to expire after %d seconds", expireAfterSeconds)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create created_at TTL index: %w", err)
+	}
+	return nil
+}
+
+// isIndexOptionsConflict reports whether index creation failed because the index exists with different options
+func isIndexOptionsConflict(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		// IndexOptionsConflict
+		return cmdErr.Code == 85
+	}
+	return false
+}
+
+// SetMetricsRecorder enables operation metrics for the repository; passing nil disables them
+func (repo *MongoRepository) SetMetricsRecorder(recorder MetricsRecorder) {
+	repo.metrics = recorder
+}
+
+// observe records the duration and outcome of a collection operation when metrics are enabled
+func (repo *MongoRepository) observe(operation string, start time.Time, err error) {
+	if repo.metrics != nil {
+		repo.metrics.ObserveOperation(operation, time.Since(start), err)
 	}
 }
 
@@ -74,29 +214,206 @@ func (repo *MongoRepository) collection() *mongo.Collection {
 func (repo *MongoRepository) CreateEntity(ctx context.Context, entity *pb.Entity) (*mongo.InsertOneResult, error) {
 	// Use the entity.Id as MongoDB's _id field
 	doc := toDocument(entity)
+	ctx, op := repo.startOperation(ctx, "CreateEntity", "insert", entity.Id)
 	result, err := repo.collection().InsertOne(ctx, doc)
+	op.end(err)
 	return result, err
 }
 
 // ReadEntity fetches an entity by ID from MongoDB
 func (repo *MongoRepository) ReadEntity(ctx context.Context, id string) (*pb.Entity, error) {
 	var doc entityDocument
+	ctx, op := repo.startOperation(ctx, "ReadEntity", "find", id)
 	err := repo.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	op.end(err)
 	if err != nil {
 		return nil, err
 	}
 	return fromDocument(&doc), nil
 }
 
+// Ping verifies that the MongoDB server is reachable
+func (repo *MongoRepository) Ping(ctx context.Context) error {
+	return repo.client.Ping(ctx, nil)
+}
+
+// EntityExists reports whether a document exists for the entity, without decoding it
+func (repo *MongoRepository) EntityExists(ctx context.Context, id string) (bool, error) {
+	ctx, op := repo.startOperation(ctx, "EntityExists", "count", id)
+	count, err := repo.collection().CountDocuments(ctx, bson.M{"_id": id}, options.Count().SetLimit(1))
+	op.end(err)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // UpdateEntity updates an entity's attributes in MongoDB
 func (repo *MongoRepository) UpdateEntity(ctx context.Context, id string, updates bson.M) (*mongo.UpdateResult, error) {
 	update := bson.M{"$set": updates}
+	ctx, op := repo.startOperation(ctx, "UpdateEntity", "update", id)
+	result, err := repo.collection().UpdateOne(ctx, bson.M{"_id": id}, update)
+	op.end(err)
+	return result, err
+}
+
+// UpdateMetadata replaces an entity's current metadata and appends the new state to its versions.
+// Documents created before versioning get their current metadata recorded as version 0 first.
+func (repo *MongoRepository) UpdateMetadata(ctx context.Context, id string, metadata map[string]*anypb.Any) (*mongo.UpdateResult, error) {
+	existingVersions := bson.M{"$ifNull": bson.A{
+		"$versions",
+		bson.A{bson.M{"version_number": 0, "updated_at": nil, "metadata": "$metadata"}},
+	}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"metadata": bson.M{"$literal": metadata},
+			"versions": bson.M{"$concatArrays": bson.A{
+				existingVersions,
+				bson.A{bson.M{
+					"version_number": bson.M{"$size": existingVersions},
+					"updated_at":     time.Now().UTC(),
+					"metadata":       bson.M{"$literal": metadata},
+				}},
+			}},
+		}}},
+	}
+	ctx, op := repo.startOperation(ctx, "UpdateMetadata", "update", id)
 	result, err := repo.collection().UpdateOne(ctx, bson.M{"_id": id}, update)
+	op.end(err)
 	return result, err
 }
 
+// ListIdsMissingKindOrCreated returns up to limit entity Ids, in Id order after afterID, whose documents lack the
+// kind or created field. Pass the last Id of one page as afterID to read the next.
+func (repo *MongoRepository) ListIdsMissingKindOrCreated(ctx context.Context, afterID string, limit int) ([]string, error) {
+	filter := bson.M{
+		"_id": bson.M{"$gt": afterID},
+		"$or": bson.A{
+			bson.M{"kind": bson.M{"$exists": false}},
+			bson.M{"created": bson.M{"$exists": false}},
+		},
+	}
+	findOptions := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.M{"_id": 1}).
+		SetLimit(int64(limit))
+
+	ctx, op := repo.startOperation(ctx, "ListIdsMissingKindOrCreated", "find", "")
+	cursor, err := repo.collection().Find(ctx, filter, findOptions)
+	if err != nil {
+		op.end(err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []entityDocument
+	err = cursor.All(ctx, &docs)
+	op.end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+// ListEntityIDs returns up to limit entity Ids in Id order, skipping the first offset. When majorKind is set only
+// documents of that major kind are listed, further narrowed by minorKind when it is set too. A limit of 0 lists all.
+func (repo *MongoRepository) ListEntityIDs(ctx context.Context, majorKind string, minorKind string, limit int64, offset int64) ([]string, error) {
+	filter := bson.M{}
+	if majorKind != "" {
+		filter["kind.major"] = majorKind
+		if minorKind != "" {
+			filter["kind.minor"] = minorKind
+		}
+	}
+	findOptions := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.M{"_id": 1}).
+		SetSkip(offset).
+		SetLimit(limit)
+
+	ctx, op := repo.startOperation(ctx, "ListEntityIDs", "find", "")
+	cursor, err := repo.collection().Find(ctx, filter, findOptions)
+	if err != nil {
+		op.end(err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []entityDocument
+	err = cursor.All(ctx, &docs)
+	op.end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+// SetKindAndCreated stores the kind and created date of an entity's document
+func (repo *MongoRepository) SetKindAndCreated(ctx context.Context, id string, kind *pb.Kind, created string) error {
+	result, err := repo.UpdateEntity(ctx, id, bson.M{"kind": kind, "created": created})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// ListEntityVersions lists the stored versions of an entity, oldest first
+func (repo *MongoRepository) ListEntityVersions(ctx context.Context, entityID string) ([]VersionSummary, error) {
+	var doc entityDocument
+	ctx, op := repo.startOperation(ctx, "ListEntityVersions", "find", entityID)
+	err := repo.collection().FindOne(ctx, bson.M{"_id": entityID}).Decode(&doc)
+	op.end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]VersionSummary, 0, len(doc.Versions))
+	for _, version := range doc.Versions {
+		versions = append(versions, VersionSummary{
+			VersionNumber: version.VersionNumber,
+			UpdatedAt:     version.UpdatedAt,
+		})
+	}
+	return versions, nil
+}
+
+// GetEntityVersion returns the entity with the metadata it had at the given version
+func (repo *MongoRepository) GetEntityVersion(ctx context.Context, entityID string, version int) (*pb.Entity, error) {
+	var doc entityDocument
+	ctx, op := repo.startOperation(ctx, "GetEntityVersion", "find", entityID)
+	err := repo.collection().FindOne(ctx, bson.M{"_id": entityID}).Decode(&doc)
+	op.end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range doc.Versions {
+		if v.VersionNumber == version {
+			entity := fromDocument(&doc)
+			entity.Metadata = v.Metadata
+			return entity, nil
+		}
+	}
+	return nil, fmt.Errorf("version %d of entity %s not found", version, entityID)
+}
+
 // DeleteEntity removes an entity from MongoDB
 func (repo *MongoRepository) DeleteEntity(ctx context.Context, id string) (*mongo.DeleteResult, error) {
+	ctx, op := repo.startOperation(ctx, "DeleteEntity", "delete", id)
 	result, err := repo.collection().DeleteOne(ctx, bson.M{"_id": id})
+	op.end(err)
 	return result, err
 }
@@ -1,12 +1,19 @@
 package mongorepository
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
@@ -40,7 +47,7 @@ func TestMain(m *testing.M) {
 
 	// Initialize MongoDB repository
 	testCtx = context.Background()
-	testRepo = NewMongoRepository(testCtx, testConfig)
+	testRepo = NewMongoRepository(testCtx, testConfig, nil)
 
 	// Clear test collection before tests
 	// testRepo.collection().Drop(testCtx)
@@ -274,3 +281,450 @@ func TestMetadataHandling(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int32(42), intWrapper.Value)
 }
+
+// TestGetAttributesAsOf verifies that attributes can be read at a point in time:
+// 1. Creates an entity whose name attribute changed twice (three time windows)
+// 2. Reads the attributes at a timestamp inside each window
+// 3. Confirms the name valid in that window is the only value returned
+// 4. Confirms reading without a timestamp returns the full history
+func TestGetAttributesAsOf(t *testing.T) {
+	entityID := "test-entity-5"
+
+	// Build the name history
+	names := []string{"Ministry of Technology", "Ministry of Digital Affairs", "Ministry of Digital Economy"}
+	windows := [][2]string{
+		{"2020-01-01T00:00:00Z", "2021-06-01T00:00:00Z"},
+		{"2021-06-01T00:00:00Z", "2023-01-01T00:00:00Z"},
+		{"2023-01-01T00:00:00Z", ""},
+	}
+	var values []*pb.TimeBasedValue
+	for i, name := range names {
+		value, err := anypb.New(wrapperspb.String(name))
+		assert.NoError(t, err)
+		values = append(values, &pb.TimeBasedValue{
+			StartTime: windows[i][0],
+			EndTime:   windows[i][1],
+			Value:     value,
+		})
+	}
+
+	entity := &pb.Entity{
+		Id: entityID,
+		Attributes: map[string]*pb.TimeBasedValueList{
+			"name": {Values: values},
+		},
+	}
+	_, err := testRepo.CreateEntity(testCtx, entity)
+	assert.NoError(t, err)
+
+	// Read the name at a timestamp inside each window
+	timestamps := []string{"2020-05-01T00:00:00Z", "2022-01-01T00:00:00Z", "2024-01-01T00:00:00Z"}
+	for i, ts := range timestamps {
+		attributes, err := testRepo.GetAttributes(testCtx, entityID, ts)
+		assert.NoError(t, err)
+		assert.Contains(t, attributes, "name")
+		assert.Equal(t, 1, len(attributes["name"].Values))
+
+		nameWrapper := &wrapperspb.StringValue{}
+		err = attributes["name"].Values[0].Value.UnmarshalTo(nameWrapper)
+		assert.NoError(t, err)
+		assert.Equal(t, names[i], nameWrapper.Value, "Expected the name valid at %s", ts)
+	}
+
+	// The end of a window is exclusive
+	attributes, err := testRepo.GetAttributes(testCtx, entityID, "2021-06-01T00:00:00Z")
+	assert.NoError(t, err)
+	nameWrapper := &wrapperspb.StringValue{}
+	err = attributes["name"].Values[0].Value.UnmarshalTo(nameWrapper)
+	assert.NoError(t, err)
+	assert.Equal(t, names[1], nameWrapper.Value)
+
+	// Before the first window there is no name
+	attributes, err = testRepo.GetAttributes(testCtx, entityID, "2019-01-01T00:00:00Z")
+	assert.NoError(t, err)
+	assert.NotContains(t, attributes, "name")
+
+	// Without a timestamp the full history is returned
+	attributes, err = testRepo.GetAttributes(testCtx, entityID, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(attributes["name"].Values))
+}
+
+// TestGetAttributeAtTime verifies reading a single attribute value at a timestamp:
+// 1. Creates an entity whose budget has a closed window, a gap, and an open-ended window
+// 2. Confirms a timestamp before every window returns nil
+// 3. Confirms a timestamp inside the closed window returns exactly that value
+// 4. Confirms a timestamp in the gap returns nil
+// 5. Confirms a timestamp after the open-ended window starts returns its value
+func TestGetAttributeAtTime(t *testing.T) {
+	entityID := "test-entity-11"
+
+	budgets := []string{"1000", "2500"}
+	windows := [][2]string{
+		{"2020-01-01T00:00:00Z", "2021-01-01T00:00:00Z"},
+		{"2022-01-01T00:00:00Z", ""},
+	}
+	var values []*pb.TimeBasedValue
+	for i, budget := range budgets {
+		value, err := anypb.New(wrapperspb.String(budget))
+		assert.NoError(t, err)
+		values = append(values, &pb.TimeBasedValue{
+			StartTime: windows[i][0],
+			EndTime:   windows[i][1],
+			Value:     value,
+		})
+	}
+
+	entity := &pb.Entity{
+		Id: entityID,
+		Attributes: map[string]*pb.TimeBasedValueList{
+			"budget": {Values: values},
+		},
+	}
+	_, err := testRepo.CreateEntity(testCtx, entity)
+	assert.NoError(t, err)
+
+	budgetAt := func(ts string) string {
+		value, err := testRepo.GetAttributeAtTime(testCtx, entityID, "budget", ts)
+		assert.NoError(t, err)
+		if value == nil {
+			return ""
+		}
+		budgetWrapper := &wrapperspb.StringValue{}
+		assert.NoError(t, value.Value.UnmarshalTo(budgetWrapper))
+		return budgetWrapper.Value
+	}
+
+	// No window contains a timestamp before the first one starts
+	assert.Equal(t, "", budgetAt("2019-01-01T00:00:00Z"))
+
+	// Exactly one window matches inside the closed window
+	assert.Equal(t, "1000", budgetAt("2020-06-01T00:00:00Z"))
+
+	// The gap between the windows has no value
+	assert.Equal(t, "", budgetAt("2021-06-01T00:00:00Z"))
+
+	// The open-ended window is still valid
+	assert.Equal(t, "2500", budgetAt("2030-01-01T00:00:00Z"))
+
+	// An attribute the entity does not have returns nil
+	value, err := testRepo.GetAttributeAtTime(testCtx, entityID, "headcount", "2020-06-01T00:00:00Z")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+// TestEntityVersioning verifies that metadata updates keep a version history:
+// 1. Creates an entity through HandleMetadata (version 0)
+// 2. Updates its metadata three times through HandleMetadata
+// 3. Confirms four versions are listed in order
+// 4. Confirms version 1 holds the state after the first update
+// 5. Confirms ReadEntity still returns the latest metadata
+func TestEntityVersioning(t *testing.T) {
+	entityID := "test-entity-6"
+
+	metadataWith := func(value string) map[string]*anypb.Any {
+		val, err := anypb.New(wrapperspb.String(value))
+		assert.NoError(t, err)
+		return map[string]*anypb.Any{"status": val}
+	}
+
+	// Create the entity
+	err := testRepo.HandleMetadata(testCtx, entityID, &pb.Entity{Id: entityID, Metadata: metadataWith("created")})
+	assert.NoError(t, err)
+
+	// Update the metadata three times
+	for _, value := range []string{"first-update", "second-update", "third-update"} {
+		err = testRepo.HandleMetadata(testCtx, entityID, &pb.Entity{Id: entityID, Metadata: metadataWith(value)})
+		assert.NoError(t, err)
+	}
+
+	// Verify the version list
+	versions, err := testRepo.ListEntityVersions(testCtx, entityID)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(versions))
+	for i, version := range versions {
+		assert.Equal(t, i, version.VersionNumber)
+	}
+
+	// Verify the state after the first update
+	versionEntity, err := testRepo.GetEntityVersion(testCtx, entityID, 1)
+	assert.NoError(t, err)
+	statusWrapper := &wrapperspb.StringValue{}
+	err = versionEntity.Metadata["status"].UnmarshalTo(statusWrapper)
+	assert.NoError(t, err)
+	assert.Equal(t, "first-update", statusWrapper.Value)
+
+	// Unknown versions return an error
+	_, err = testRepo.GetEntityVersion(testCtx, entityID, 4)
+	assert.Error(t, err)
+
+	// ReadEntity returns the latest version
+	readEntity, err := testRepo.ReadEntity(testCtx, entityID)
+	assert.NoError(t, err)
+	err = readEntity.Metadata["status"].UnmarshalTo(statusWrapper)
+	assert.NoError(t, err)
+	assert.Equal(t, "third-update", statusWrapper.Value)
+}
+
+// TestCreateDuplicateEntity verifies that an entity Id can only be inserted once:
+// 1. Ensures the collection indexes exist
+// 2. Creates an entity
+// 3. Creates a second entity with the same Id
+// 4. Confirms the second insert fails with a duplicate key error
+func TestCreateDuplicateEntity(t *testing.T) {
+	entityID := "test-entity-duplicate"
+	testRepo.DeleteEntity(testCtx, entityID)
+
+	err := testRepo.EnsureIndexes(testCtx)
+	assert.Nil(t, err, "Expected no error when ensuring indexes")
+
+	_, err = testRepo.CreateEntity(testCtx, &pb.Entity{Id: entityID})
+	assert.Nil(t, err, "Expected no error when creating the entity")
+
+	_, err = testRepo.CreateEntity(testCtx, &pb.Entity{Id: entityID})
+	assert.NotNil(t, err, "Expected an error when creating the entity a second time")
+	assert.True(t, mongo.IsDuplicateKeyError(err), "Expected a duplicate key error")
+}
+
+// TestGetMetadataField verifies that a single metadata value can be read without the rest of the map:
+// 1. Creates an entity with three metadata keys of different types
+// 2. Reads one key by name and confirms its type and value survive the round trip
+// 3. Confirms a missing key returns ErrMetadataKeyNotFound
+// 4. Confirms a missing entity returns mongo.ErrNoDocuments
+func TestGetMetadataField(t *testing.T) {
+	entityID := "test-entity-7"
+	testRepo.DeleteEntity(testCtx, entityID)
+
+	stringVal, err := anypb.New(wrapperspb.String("field-value"))
+	assert.NoError(t, err)
+	intVal, err := anypb.New(wrapperspb.Int32(7))
+	assert.NoError(t, err)
+	boolVal, err := anypb.New(wrapperspb.Bool(false))
+	assert.NoError(t, err)
+
+	_, err = testRepo.CreateEntity(testCtx, &pb.Entity{
+		Id: entityID,
+		Metadata: map[string]*anypb.Any{
+			"string":  stringVal,
+			"number":  intVal,
+			"boolean": boolVal,
+		},
+	})
+	assert.NoError(t, err)
+
+	value, err := testRepo.GetMetadataField(testCtx, entityID, "number")
+	assert.NoError(t, err)
+	intWrapper := &wrapperspb.Int32Value{}
+	assert.NoError(t, value.UnmarshalTo(intWrapper), "Expected the value to unmarshal as its original type")
+	assert.Equal(t, int32(7), intWrapper.Value)
+
+	_, err = testRepo.GetMetadataField(testCtx, entityID, "missing")
+	assert.ErrorIs(t, err, ErrMetadataKeyNotFound, "Expected a not-found error for a missing key")
+
+	_, err = testRepo.GetMetadataField(testCtx, "test-entity-missing", "number")
+	assert.ErrorIs(t, err, mongo.ErrNoDocuments, "Expected no documents for a missing entity")
+}
+
+// TestEntityExists verifies the existence check without reading the document:
+// 1. Creates an entity
+// 2. Confirms EntityExists reports true for it
+// 3. Confirms EntityExists reports false for an unknown Id without an error
+func TestEntityExists(t *testing.T) {
+	entityID := "test-entity-8"
+	testRepo.DeleteEntity(testCtx, entityID)
+
+	_, err := testRepo.CreateEntity(testCtx, &pb.Entity{Id: entityID})
+	assert.NoError(t, err)
+
+	exists, err := testRepo.EntityExists(testCtx, entityID)
+	assert.NoError(t, err)
+	assert.True(t, exists, "Expected the created entity to exist")
+
+	exists, err = testRepo.EntityExists(testCtx, "test-entity-missing")
+	assert.NoError(t, err)
+	assert.False(t, exists, "Expected an unknown entity not to exist")
+}
+
+// TestExportAll verifies the NDJSON metadata export:
+// 1. Creates two entities with metadata
+// 2. Exports the collection into a buffer
+// 3. Parses every line back and checks the count and the decoded metadata of both entities
+func TestExportAll(t *testing.T) {
+	entityIDs := []string{"test-entity-9", "test-entity-10"}
+	for i, entityID := range entityIDs {
+		testRepo.DeleteEntity(testCtx, entityID)
+		nameValue, _ := anypb.New(wrapperspb.String(fmt.Sprintf("Export %d", i)))
+		countValue, _ := anypb.New(wrapperspb.Int32(int32(i)))
+		_, err := testRepo.CreateEntity(testCtx, &pb.Entity{
+			Id:       entityID,
+			Metadata: map[string]*anypb.Any{"name": nameValue, "count": countValue},
+		})
+		assert.NoError(t, err)
+	}
+
+	var buffer bytes.Buffer
+	count, err := testRepo.ExportAll(testCtx, &buffer)
+	assert.NoError(t, err)
+
+	exported := make(map[string]map[string]interface{})
+	scanner := bufio.NewScanner(&buffer)
+	lines := 0
+	for scanner.Scan() {
+		var line struct {
+			ID       string                 `json:"id"`
+			Metadata map[string]interface{} `json:"metadata"`
+		}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line), "Expected each line to be a JSON object")
+		exported[line.ID] = line.Metadata
+		lines++
+	}
+	assert.Equal(t, count, lines, "Expected one line per exported entity")
+
+	for i, entityID := range entityIDs {
+		metadata, ok := exported[entityID]
+		if !assert.True(t, ok, "Expected entity %s in the export", entityID) {
+			continue
+		}
+		assert.Equal(t, fmt.Sprintf("Export %d", i), metadata["name"], "Expected the decoded string value")
+		assert.Equal(t, float64(i), metadata["count"], "Expected the decoded integer value")
+	}
+}
+
+// TestListEntityIDs verifies listing entity Ids by kind:
+// 1. Creates ten entities split across three kinds
+// 2. Lists by major kind and by major and minor kind and confirms only the matching Ids are returned
+// 3. Pages through one kind with limit and offset
+func TestListEntityIDs(t *testing.T) {
+	err := testRepo.EnsureIndexes(testCtx)
+	assert.NoError(t, err, "Expected no error when ensuring indexes")
+
+	kinds := []*pb.Kind{
+		{Major: "ListTestPerson", Minor: "Employee"},
+		{Major: "ListTestPerson", Minor: "Contractor"},
+		{Major: "ListTestOrganization", Minor: "Company"},
+	}
+	expected := make(map[string][]string)
+	for i := 0; i < 10; i++ {
+		entityID := fmt.Sprintf("test-list-entity-%02d", i)
+		testRepo.DeleteEntity(testCtx, entityID)
+		kind := kinds[i%len(kinds)]
+		_, err := testRepo.CreateEntity(testCtx, &pb.Entity{Id: entityID, Kind: kind, Created: "2025-01-01T00:00:00Z"})
+		assert.NoError(t, err)
+		expected[kind.Major] = append(expected[kind.Major], entityID)
+		expected[kind.Major+"/"+kind.Minor] = append(expected[kind.Major+"/"+kind.Minor], entityID)
+	}
+
+	ids, err := testRepo.ListEntityIDs(testCtx, "ListTestPerson", "", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected["ListTestPerson"], ids, "Expected only the Person entities")
+
+	ids, err = testRepo.ListEntityIDs(testCtx, "ListTestPerson", "Contractor", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected["ListTestPerson/Contractor"], ids, "Expected only the contractors")
+
+	ids, err = testRepo.ListEntityIDs(testCtx, "ListTestOrganization", "", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected["ListTestOrganization"], ids, "Expected only the organizations")
+
+	ids, err = testRepo.ListEntityIDs(testCtx, "ListTestPerson", "", 3, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, expected["ListTestPerson"][2:5], ids, "Expected the second page of Person entities")
+}
+
+// TestEnsureIndexesIdempotent verifies that the indexes can be ensured on every startup:
+// 1. Calls EnsureIndexes twice and confirms neither call fails
+// 2. Lists the collection indexes through the driver
+// 3. Confirms the unique index on the entity Id (_id) and the kind index exist
+func TestEnsureIndexesIdempotent(t *testing.T) {
+	assert.NoError(t, testRepo.EnsureIndexes(testCtx), "Expected no error on the first call")
+	assert.NoError(t, testRepo.EnsureIndexes(testCtx), "Expected no error when the indexes already exist")
+
+	cursor, err := testRepo.collection().Indexes().List(testCtx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var indexes []bson.M
+	assert.NoError(t, cursor.All(testCtx, &indexes))
+
+	names := make(map[string]bool)
+	for _, index := range indexes {
+		if name, ok := index["name"].(string); ok {
+			names[name] = true
+		}
+	}
+	assert.True(t, names["_id_"], "Expected the entity Id index")
+	assert.True(t, names["kind"], "Expected the kind index")
+}
+
+// TestEnsureIndexesUpdatesTTL verifies that a changed entity TTL is applied to the existing TTL index:
+// 1. Ensures the indexes with a one hour TTL
+// 2. Ensures them again with a two hour TTL and confirms the index expiry was updated
+func TestEnsureIndexesUpdatesTTL(t *testing.T) {
+	previousTTL := testRepo.config.EntityTTL
+	defer func() {
+		testRepo.config.EntityTTL = previousTTL
+		testRepo.collection().Indexes().DropOne(testCtx, ttlIndexName)
+	}()
+
+	testRepo.config.EntityTTL = time.Hour
+	assert.NoError(t, testRepo.EnsureIndexes(testCtx), "Expected no error when creating the TTL index")
+	testRepo.config.EntityTTL = 2 * time.Hour
+	assert.NoError(t, testRepo.EnsureIndexes(testCtx), "Expected no error when changing the TTL")
+
+	cursor, err := testRepo.collection().Indexes().List(testCtx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var indexes []bson.M
+	assert.NoError(t, cursor.All(testCtx, &indexes))
+
+	var expireAfterSeconds interface{}
+	for _, index := range indexes {
+		if index["name"] == ttlIndexName {
+			expireAfterSeconds = index["expireAfterSeconds"]
+		}
+	}
+	assert.EqualValues(t, 7200, expireAfterSeconds, "Expected the TTL index to expire after two hours")
+}
+
+// TestGetMetadataStats verifies the metadata statistics of a kind:
+// 1. Creates twenty entities whose metadata has between zero and four keys (key0, key0..key1, and so on)
+// 2. Confirms the totals, the average key count and the key order by frequency
+// 3. Adds another entity and confirms the cached result is returned
+func TestGetMetadataStats(t *testing.T) {
+	kind := &pb.Kind{Major: "StatsTestPerson", Minor: "Employee"}
+	testRepo.metadataStats.Delete(kind.Major)
+	testRepo.DeleteEntity(testCtx, "test-stats-entity-20")
+
+	for i := 0; i < 20; i++ {
+		entityID := fmt.Sprintf("test-stats-entity-%02d", i)
+		testRepo.DeleteEntity(testCtx, entityID)
+		metadata := make(map[string]*anypb.Any)
+		for k := 0; k < i%5; k++ {
+			value, err := anypb.New(wrapperspb.String(fmt.Sprintf("value-%d", k)))
+			assert.NoError(t, err)
+			metadata[fmt.Sprintf("key%d", k)] = value
+		}
+		_, err := testRepo.CreateEntity(testCtx, &pb.Entity{Id: entityID, Kind: kind, Metadata: metadata})
+		assert.NoError(t, err)
+	}
+
+	stats, err := testRepo.GetMetadataStats(testCtx, kind.Major)
+	assert.NoError(t, err, "Expected no error when computing metadata stats")
+	assert.Equal(t, int64(20), stats.TotalEntities)
+	assert.Equal(t, int64(16), stats.EntitiesWithMetadata, "Expected the four entities without keys to be excluded")
+	assert.InDelta(t, 2.0, stats.AverageMetadataKeyCount, 0.0001, "Expected (0+1+2+3+4)*4/20 keys on average")
+	assert.Equal(t, []string{"key0", "key1", "key2", "key3"}, stats.MostCommonKeys, "Expected the keys ordered by frequency")
+
+	// The result is cached, so a new entity is not counted until the cache expires
+	_, err = testRepo.CreateEntity(testCtx, &pb.Entity{Id: "test-stats-entity-20", Kind: kind})
+	assert.NoError(t, err)
+	cached, err := testRepo.GetMetadataStats(testCtx, kind.Major)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), cached.TotalEntities, "Expected the cached stats to be returned")
+
+	empty, err := testRepo.GetMetadataStats(testCtx, "StatsTestMissing")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), empty.TotalEntities, "Expected no entities for an unknown kind")
+	assert.Empty(t, empty.MostCommonKeys)
+}
@@ -0,0 +1,48 @@
+package mongorepository
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans created by the repository
+const tracerName = "lk/datafoundation/crud-api/db/repository/mongo"
+
+// operation is a single collection operation, timed for metrics and traced as a span
+type operation struct {
+	repo  *MongoRepository
+	name  string
+	start time.Time
+	span  trace.Span
+}
+
+// startOperation starts a span named mongo.<name> as a child of ctx and returns the context carrying it.
+// The tracer is looked up on every call so a provider installed with otel.SetTracerProvider after startup is used.
+func (repo *MongoRepository) startOperation(ctx context.Context, name string, queryType string, entityID string) (context.Context, *operation) {
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", "mongodb"),
+		attribute.String("query.type", queryType),
+	}
+	if entityID != "" {
+		attrs = append(attrs, attribute.String("entity.id", entityID))
+	}
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "mongo."+name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return ctx, &operation{repo: repo, name: name, start: time.Now(), span: span}
+}
+
+// end records the outcome of the operation and ends its span
+func (op *operation) end(err error) {
+	op.repo.observe(op.name, op.start, err)
+	if err != nil {
+		op.span.RecordError(err)
+		op.span.SetStatus(codes.Error, err.Error())
+	} else {
+		op.span.SetStatus(codes.Ok, "")
+	}
+	op.span.End()
+}
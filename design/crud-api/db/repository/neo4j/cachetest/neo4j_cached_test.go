@@ -0,0 +1,165 @@
+// Package cachetest tests CachedNeo4jRepository against a fake repository. The tests live outside the
+// neo4jrepository package, whose TestMain needs a live Neo4j database, so they also run without one.
+package cachetest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	neo4jrepository "lk/datafoundation/crud-api/db/repository/neo4j"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGraphRepository counts the calls made to the wrapped repository
+type fakeGraphRepository struct {
+	neo4jrepository.GraphRepository
+	readCalls int
+}
+
+func (f *fakeGraphRepository) ReadGraphEntity(ctx context.Context, entityID string) (map[string]interface{}, error) {
+	f.readCalls++
+	if entityID == "missing" {
+		return nil, fmt.Errorf("entity with Id %s not found", entityID)
+	}
+	entity := map[string]interface{}{
+		"Id":        entityID,
+		"Name":      "Cached Entity",
+		"Created":   "2025-03-18T00:00:00Z",
+		"MajorKind": "Person",
+		"MinorKind": "Minister",
+	}
+	// Entities with a tenant- prefix belong to tenantA and are only found within it
+	if strings.HasPrefix(entityID, "tenant-") {
+		entity["Tenant"] = "tenantA"
+	}
+	if tenant, _ := entity["Tenant"].(string); tenant != neo4jrepository.TenantFromContext(ctx) {
+		return nil, fmt.Errorf("entity with Id %s not found", entityID)
+	}
+	return entity, nil
+}
+
+func (f *fakeGraphRepository) UpdateGraphEntity(ctx context.Context, id string, updateData map[string]interface{}) (map[string]interface{}, error) {
+	return updateData, nil
+}
+
+func (f *fakeGraphRepository) DeleteGraphEntity(ctx context.Context, entityID string) error {
+	return nil
+}
+
+func (f *fakeGraphRepository) TerminateGraphEntity(ctx context.Context, entityID string, terminatedAt string) (map[string]interface{}, error) {
+	return map[string]interface{}{"Id": entityID, "Terminated": terminatedAt}, nil
+}
+
+func (f *fakeGraphRepository) DeleteEntityReassigning(ctx context.Context, deleteID, newParentID, relType string) error {
+	return nil
+}
+
+// TestCachedReadGraphEntity verifies repeated reads within the TTL only hit the wrapped repository once
+func TestCachedReadGraphEntity(t *testing.T) {
+	fake := &fakeGraphRepository{}
+	cached := neo4jrepository.NewCachedNeo4jRepository(fake, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		entity, err := cached.ReadGraphEntity(context.Background(), "cached-1")
+		assert.Nil(t, err, "Expected no error when reading the entity")
+		assert.Equal(t, "cached-1", entity["Id"])
+	}
+	assert.Equal(t, 1, fake.readCalls, "Expected a single call to the wrapped repository")
+
+	// GetGraphEntity goes through the cache as well
+	kind, _, created, _, err := cached.GetGraphEntity(context.Background(), "cached-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "Person", kind.Major)
+	assert.Equal(t, "2025-03-18T00:00:00Z", created)
+	assert.Equal(t, 1, fake.readCalls, "Expected GetGraphEntity to use the cache")
+
+	// Modifying a returned map does not modify the cache
+	entity, _ := cached.ReadGraphEntity(context.Background(), "cached-1")
+	entity["Name"] = "Changed"
+	entity, _ = cached.ReadGraphEntity(context.Background(), "cached-1")
+	assert.Equal(t, "Cached Entity", entity["Name"])
+}
+
+// TestCachedReadGraphEntityExpiry verifies entries are refreshed once the TTL has passed
+func TestCachedReadGraphEntityExpiry(t *testing.T) {
+	fake := &fakeGraphRepository{}
+	cached := neo4jrepository.NewCachedNeo4jRepository(fake, 10*time.Millisecond)
+
+	_, err := cached.ReadGraphEntity(context.Background(), "cached-2")
+	assert.Nil(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = cached.ReadGraphEntity(context.Background(), "cached-2")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, fake.readCalls, "Expected the expired entry to be read again")
+}
+
+// TestCachedInvalidation verifies updates and deletes invalidate the cached entity
+func TestCachedInvalidation(t *testing.T) {
+	fake := &fakeGraphRepository{}
+	cached := neo4jrepository.NewCachedNeo4jRepository(fake, time.Minute)
+	ctx := context.Background()
+
+	_, _ = cached.ReadGraphEntity(ctx, "cached-3")
+	_, err := cached.UpdateGraphEntity(ctx, "cached-3", map[string]interface{}{"Name": "Updated"})
+	assert.Nil(t, err)
+	_, _ = cached.ReadGraphEntity(ctx, "cached-3")
+	assert.Equal(t, 2, fake.readCalls, "Expected a read after an update to hit the wrapped repository")
+
+	err = cached.DeleteGraphEntity(ctx, "cached-3")
+	assert.Nil(t, err)
+	_, _ = cached.ReadGraphEntity(ctx, "cached-3")
+	assert.Equal(t, 3, fake.readCalls, "Expected a read after a delete to hit the wrapped repository")
+
+	err = cached.DeleteEntityReassigning(ctx, "cached-3", "cached-5", "HAS_CHILD")
+	assert.Nil(t, err)
+	_, _ = cached.ReadGraphEntity(ctx, "cached-3")
+	assert.Equal(t, 4, fake.readCalls, "Expected a read after a reassigning delete to hit the wrapped repository")
+
+	_, err = cached.TerminateGraphEntity(ctx, "cached-3", "2025-06-01T00:00:00Z")
+	assert.Nil(t, err)
+	_, _ = cached.ReadGraphEntity(ctx, "cached-3")
+	assert.Equal(t, 5, fake.readCalls, "Expected a read after a termination to hit the wrapped repository")
+
+	// Other entities are not affected
+	_, _ = cached.ReadGraphEntity(ctx, "cached-4")
+	_, _ = cached.UpdateGraphEntity(ctx, "cached-3", map[string]interface{}{"Name": "Updated"})
+	_, _ = cached.ReadGraphEntity(ctx, "cached-4")
+	assert.Equal(t, 6, fake.readCalls, "Expected unrelated entries to stay cached")
+}
+
+// TestCachedReadGraphEntityError verifies errors are not cached
+func TestCachedReadGraphEntityError(t *testing.T) {
+	fake := &fakeGraphRepository{}
+	cached := neo4jrepository.NewCachedNeo4jRepository(fake, time.Minute)
+
+	_, err := cached.ReadGraphEntity(context.Background(), "missing")
+	assert.NotNil(t, err)
+	_, err = cached.ReadGraphEntity(context.Background(), "missing")
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, fake.readCalls, "Expected failed reads not to be cached")
+}
+
+// TestCachedReadGraphEntityTenant verifies a cached entity is not returned to another tenant
+func TestCachedReadGraphEntityTenant(t *testing.T) {
+	fake := &fakeGraphRepository{}
+	cached := neo4jrepository.NewCachedNeo4jRepository(fake, time.Minute)
+	ctxA := neo4jrepository.WithTenant(context.Background(), "tenantA")
+
+	entity, err := cached.ReadGraphEntity(ctxA, "tenant-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "tenantA", entity["Tenant"])
+
+	_, err = cached.ReadGraphEntity(neo4jrepository.WithTenant(context.Background(), "tenantB"), "tenant-1")
+	assert.NotNil(t, err, "Expected tenant B not to read the cached tenant A entity")
+	_, err = cached.ReadGraphEntity(context.Background(), "tenant-1")
+	assert.NotNil(t, err, "Expected a shared read not to see the cached tenant A entity")
+
+	_, err = cached.ReadGraphEntity(ctxA, "tenant-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, fake.readCalls, "Expected only the reads of the owning tenant to use the cache")
+}
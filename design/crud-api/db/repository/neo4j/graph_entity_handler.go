@@ -3,25 +3,31 @@ package neo4jrepository
 import (
 	"context"
 	"fmt"
-	"log"
+	"time"
 
 	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api" // Replace with your actual protobuf package
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // GetEntityDetailsFromNeo4j retrieves entity information from Neo4j database
 func (repo *Neo4jRepository) GetGraphEntity(ctx context.Context, entityId string) (*pb.Kind, *pb.TimeBasedValue, string, string, error) {
-	// Try to get additional entity information from Neo4j
+	// Attempt to read from Neo4j, but don't fail if it's not available
+	entityMap, err := repo.ReadGraphEntity(ctx, entityId)
+	kind, name, created, terminated := graphEntityFromMap(entityMap)
+	return kind, name, created, terminated, err
+}
+
+// graphEntityFromMap converts an entity map returned by ReadGraphEntity into its protobuf parts
+func graphEntityFromMap(entityMap map[string]interface{}) (*pb.Kind, *pb.TimeBasedValue, string, string) {
 	var kind *pb.Kind
 	var name *pb.TimeBasedValue
 	var created string
 	var terminated string
 
-	// Attempt to read from Neo4j, but don't fail if it's not available
-	entityMap, err := repo.ReadGraphEntity(ctx, entityId)
-	if err == nil && entityMap != nil {
+	if entityMap != nil {
 
 		// Entity found in Neo4j, extract information
 		if majorKindValue, ok := entityMap["MajorKind"]; ok {
@@ -37,6 +43,10 @@ func (repo *Neo4jRepository) GetGraphEntity(ctx context.Context, entityId string
 			kind.Minor = minorKindValue.(string)
 		}
 
+		if tenantValue, ok := entityMap["Tenant"]; ok && kind != nil {
+			kind.Tenant = tenantValue.(string)
+		}
+
 		if nameValue, ok := entityMap["Name"]; ok {
 			// Create a TimeBasedValue with string value
 			value, _ := anypb.New(&wrapperspb.StringValue{
@@ -63,23 +73,50 @@ func (repo *Neo4jRepository) GetGraphEntity(ctx context.Context, entityId string
 		}
 	}
 
-	return kind, name, created, terminated, err
+	return kind, name, created, terminated
 }
 
-// GetGraphRelationships retrieves relationships for an entity from Neo4j
-func (repo *Neo4jRepository) GetGraphRelationships(ctx context.Context, entityId string) (map[string]*pb.Relationship, error) {
+// GetGraphRelationships retrieves the outgoing and incoming relationships of an entity from Neo4j.
+// Every relationship is returned, including several of the same type, tagged with its direction.
+func (repo *Neo4jRepository) GetGraphRelationships(ctx context.Context, entityId string) ([]*pb.RelationshipWithDirection, error) {
+	return repo.graphRelationships(ctx, entityId, "")
+}
+
+// GetGraphRelationshipsAsOf retrieves the outgoing relationships of an entity that are valid at the given timestamp,
+// keyed by relationship Id. An empty asOf returns all relationships regardless of their temporal validity.
+func (repo *Neo4jRepository) GetGraphRelationshipsAsOf(ctx context.Context, entityId string, asOf string) (map[string]*pb.Relationship, error) {
+	relationships, err := repo.graphRelationships(ctx, entityId, asOf)
+	if err != nil {
+		return make(map[string]*pb.Relationship), err
+	}
+	return OutgoingRelationships(relationships), nil
+}
+
+// OutgoingRelationships keys the outgoing relationships in rels by relationship Id, as stored on pb.Entity
+func OutgoingRelationships(rels []*pb.RelationshipWithDirection) map[string]*pb.Relationship {
 	relationships := make(map[string]*pb.Relationship)
+	for _, rel := range rels {
+		if rel.Direction == pb.Direction_OUTGOING {
+			relationships[rel.Relationship.Id] = rel.Relationship
+		}
+	}
+	return relationships
+}
+
+// graphRelationships reads the relationships of an entity in both directions, optionally restricted to those valid at asOf
+func (repo *Neo4jRepository) graphRelationships(ctx context.Context, entityId string, asOf string) ([]*pb.RelationshipWithDirection, error) {
 	// Retrieve relationships from Neo4j
-	relData, err := repo.ReadRelationships(ctx, entityId)
+	relData, err := repo.readRelationships(ctx, entityId, asOf, nil, DirectionBoth)
 	if err != nil {
-		log.Printf("[neo4j_handler.GetGraphRelationships] Error reading relationships for entity %s: %v", entityId, err)
-		return relationships, fmt.Errorf("[neo4j_handler.GetGraphRelationships] error reading relationships: %v", err)
+		repo.logger.Errorf("[neo4j_handler.GetGraphRelationships] Error reading relationships for entity %s: %v", entityId, err)
+		return nil, fmt.Errorf("[neo4j_handler.GetGraphRelationships] error reading relationships: %v", err)
 	}
 
 	// Process each relationship
 	// TODO: Holding relationship and defining the content needs to be
 	//  revalidated. Discuss and confirm.
 	//  Also build a rule based validation for the relationship content.
+	var relationships []*pb.RelationshipWithDirection
 	for _, rel := range relData {
 		relType, ok1 := rel["type"].(string)
 		relatedID, ok2 := rel["relatedID"].(string)
@@ -87,7 +124,7 @@ func (repo *Neo4jRepository) GetGraphRelationships(ctx context.Context, entityId
 		relID, ok4 := rel["relationshipID"].(string)
 		direction, ok5 := rel["direction"].(string)
 
-		if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || direction != "OUTGOING" {
+		if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
 			continue // Skip if any required field is missing
 		}
 
@@ -104,13 +141,27 @@ func (repo *Neo4jRepository) GetGraphRelationships(ctx context.Context, entityId
 			relationship.EndTime = terminated
 		}
 
-		// Store in map with unique key
-		relationships[relID] = relationship
+		// Add typed properties if available
+		if properties, ok := rel["properties"].(map[string]*anypb.Any); ok {
+			relationship.Properties = properties
+		}
+
+		relDirection := pb.Direction_OUTGOING
+		if direction == string(DirectionIncoming) {
+			relDirection = pb.Direction_INCOMING
+		}
+
+		relationships = append(relationships, &pb.RelationshipWithDirection{
+			Relationship:    relationship,
+			Direction:       relDirection,
+			RelatedEntityId: relatedID,
+		})
 	}
 
 	return relationships, nil
 }
 
+// GetRelationshipsByName returns the outgoing relationships of the given type that are valid at ts, keyed by relationship Id
 func (repo *Neo4jRepository) GetRelationshipsByName(ctx context.Context, entityId string, relationship string, ts string) (map[string]*pb.Relationship, error) {
 	// Validate input parameters
 	if entityId == "" {
@@ -119,6 +170,9 @@ func (repo *Neo4jRepository) GetRelationshipsByName(ctx context.Context, entityI
 	if relationship == "" {
 		return nil, fmt.Errorf("relationship type cannot be empty")
 	}
+	if !labelPattern.MatchString(relationship) {
+		return nil, fmt.Errorf("invalid relationship type %q: only letters, digits and '_' are allowed", relationship)
+	}
 	if ts == "" {
 		return nil, fmt.Errorf("timestamp cannot be empty")
 	}
@@ -126,7 +180,7 @@ func (repo *Neo4jRepository) GetRelationshipsByName(ctx context.Context, entityI
 	// Call ReadRelatedGraphEntityIds from neo4j_client.go
 	relationshipData, err := repo.ReadRelatedGraphEntityIds(ctx, entityId, relationship, ts)
 	if err != nil {
-		log.Printf("[GetEntityIdsByRelationship] Error fetching related relationships for entity %s with relationship %s: %v", entityId, relationship, err)
+		repo.logger.Errorf("[GetEntityIdsByRelationship] Error fetching related relationships for entity %s with relationship %s: %v", entityId, relationship, err)
 		return nil, err
 	}
 
@@ -141,7 +195,7 @@ func (repo *Neo4jRepository) GetRelationshipsByName(ctx context.Context, entityI
 
 		// Ensure required fields are present
 		if !ok1 || !ok2 || !ok3 || !ok4 {
-			log.Printf("[GetEntityIdsByRelationship] Skipping relationship due to missing required fields: %v", rel)
+			repo.logger.Warnf("[GetEntityIdsByRelationship] Skipping relationship due to missing required fields: %v", rel)
 			continue
 		}
 
@@ -159,38 +213,60 @@ func (repo *Neo4jRepository) GetRelationshipsByName(ctx context.Context, entityI
 }
 
 // validateGraphEntityCreation checks if an entity has all required fields for Neo4j storage
-func validateGraphEntityCreation(entity *pb.Entity) bool {
+func (repo *Neo4jRepository) validateGraphEntityCreation(entity *pb.Entity) bool {
 	// Check if Kind is present and has a Major value
 	if entity.Kind == nil || entity.Kind.GetMajor() == "" {
-		log.Printf("[neo4j_handler.validateGraphEntityCreation] Skipping Neo4j entity creation for %s: Missing or empty Kind.Major", entity.Id)
+		repo.logger.Warnf("[neo4j_handler.validateGraphEntityCreation] Skipping Neo4j entity creation for %s: Missing or empty Kind.Major", entity.Id)
 		return false
 	}
 
 	// Check if Name is present and has a Value
 	if entity.Name == nil || entity.Name.GetValue() == nil {
-		log.Printf("[neo4j_handler.validateGraphEntityCreation] Skipping Neo4j entity creation for %s: Missing or empty Name.Value", entity.Id)
+		repo.logger.Warnf("[neo4j_handler.validateGraphEntityCreation] Skipping Neo4j entity creation for %s: Missing or empty Name.Value", entity.Id)
 		return false
 	}
 
 	// Check if Created date is present
 	if entity.Created == "" {
-		log.Printf("[neo4j_handler.validateGraphEntityCreation] Skipping Neo4j entity creation for %s: Missing Created date", entity.Id)
+		repo.logger.Warnf("[neo4j_handler.validateGraphEntityCreation] Skipping Neo4j entity creation for %s: Missing Created date", entity.Id)
 		return false
 	}
 
 	return true
 }
 
-// HandleGraphEntityCreation creates a new entity in Neo4j
-func (repo *Neo4jRepository) HandleGraphEntityCreation(ctx context.Context, entity *pb.Entity) (bool, error) {
-	// Validate required fields for Neo4j entity creation
-	if !validateGraphEntityCreation(entity) {
-		log.Printf("[neo4j_handler.HandleGraphEntityCreation] Entity %s saved in MongoDB only, skipping Neo4j due to missing required fields", entity.Id)
-		return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] missing required fields for Neo4j entity creation")
+// ValidateTimeInterval checks that an interval's end time does not precede its start time.
+// An empty end time is treated as open-ended. Times are RFC3339, with plain dates (2006-01-02) also accepted.
+func ValidateTimeInterval(start, end string) error {
+	if end == "" {
+		return nil
+	}
+
+	startTime, err := parseIntervalTime(start)
+	if err != nil {
+		return fmt.Errorf("invalid start time %q: %v", start, err)
+	}
+	endTime, err := parseIntervalTime(end)
+	if err != nil {
+		return fmt.Errorf("invalid end time %q: %v", end, err)
+	}
+
+	if endTime.Before(startTime) {
+		return fmt.Errorf("end time %s precedes start time %s", end, start)
 	}
+	return nil
+}
 
-	log.Printf("[neo4j_handler.HandleGraphEntityCreation] Creating new entity in Neo4j: %s", entity.Id)
+// parseIntervalTime parses an RFC3339 timestamp or a plain date
+func parseIntervalTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
 
+// graphEntityParams converts an entity into the kind and property map used to create its node
+func (repo *Neo4jRepository) graphEntityParams(entity *pb.Entity) (*pb.Kind, map[string]interface{}, error) {
 	// Prepare data for Neo4j with safety checks
 	entityMap := map[string]interface{}{
 		"Id": entity.Id,
@@ -198,12 +274,13 @@ func (repo *Neo4jRepository) HandleGraphEntityCreation(ctx context.Context, enti
 
 	// Validate and extract the Kind field
 	if entity.Kind == nil || entity.Kind.GetMajor() == "" || entity.Kind.GetMinor() == "" {
-		return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] missing or invalid Kind.Major or Kind.Minor for entity %s", entity.Id)
+		return nil, nil, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] missing or invalid Kind.Major or Kind.Minor for entity %s", entity.Id)
 	}
 
 	kind := &pb.Kind{
-		Major: entity.Kind.GetMajor(),
-		Minor: entity.Kind.GetMinor(),
+		Major:  entity.Kind.GetMajor(),
+		Minor:  entity.Kind.GetMinor(),
+		Tenant: entity.Kind.GetTenant(),
 	}
 
 	// Handle Name field safely
@@ -212,8 +289,8 @@ func (repo *Neo4jRepository) HandleGraphEntityCreation(ctx context.Context, enti
 		var stringValue wrapperspb.StringValue
 		err := entity.Name.GetValue().UnmarshalTo(&stringValue)
 		if err != nil {
-			log.Printf("[neo4j_handler.HandleGraphEntityCreation] Error unpacking Name value for entity %s: %v", entity.Id, err)
-			return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] error unpacking Name value: %v", err)
+			repo.logger.WithField("entity_id", entity.Id).Errorf("[neo4j_handler.HandleGraphEntityCreation] Error unpacking Name value for entity %s: %v", entity.Id, err)
+			return nil, nil, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] error unpacking Name value: %v", err)
 		}
 		// Get the actual string value from the StringValue
 		entityMap["Name"] = stringValue.Value
@@ -228,26 +305,46 @@ func (repo *Neo4jRepository) HandleGraphEntityCreation(ctx context.Context, enti
 		entityMap["Terminated"] = entity.Terminated
 	}
 
+	return kind, entityMap, nil
+}
+
+// HandleGraphEntityCreation creates a new entity in Neo4j
+func (repo *Neo4jRepository) HandleGraphEntityCreation(ctx context.Context, entity *pb.Entity) (bool, error) {
+	logger := repo.logger.WithField("entity_id", entity.Id)
+	// Validate required fields for Neo4j entity creation
+	if !repo.validateGraphEntityCreation(entity) {
+		logger.Warnf("[neo4j_handler.HandleGraphEntityCreation] Entity %s saved in MongoDB only, skipping Neo4j due to missing required fields", entity.Id)
+		return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] missing required fields for Neo4j entity creation")
+	}
+
+	logger.Infof("[neo4j_handler.HandleGraphEntityCreation] Creating new entity in Neo4j: %s", entity.Id)
+
+	kind, entityMap, err := repo.graphEntityParams(entity)
+	if err != nil {
+		return false, err
+	}
+
 	// Create the entity
 	result, err := repo.CreateGraphEntity(ctx, kind, entityMap)
 	if err != nil {
-		log.Printf("[neo4j_handler.HandleGraphEntityCreation] Error creating entity in Neo4j: %v", err)
+		logger.Errorf("[neo4j_handler.HandleGraphEntityCreation] Error creating entity in Neo4j: %v", err)
 		return false, err
 	} else {
-		log.Printf("[neo4j_handler.HandleGraphEntityCreation] Successfully created entity in Neo4j: %s", entity.Id)
+		logger.Infof("[neo4j_handler.HandleGraphEntityCreation] Successfully created entity in Neo4j: %s", entity.Id)
 		return result != nil, nil // Success if we got a non-nil result
 	}
 }
 
 // HandleGraphEntityUpdate updates an existing entity in Neo4j
 func (repo *Neo4jRepository) HandleGraphEntityUpdate(ctx context.Context, entity *pb.Entity) (bool, error) {
+	logger := repo.logger.WithField("entity_id", entity.Id)
 	// Validate required fields for Neo4j entity update
-	if !validateGraphEntityCreation(entity) {
-		log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Entity %s saved in MongoDB only, skipping Neo4j due to missing required fields", entity.Id)
+	if !repo.validateGraphEntityCreation(entity) {
+		logger.Warnf("[neo4j_handler.HandleGraphEntityUpdate] Entity %s saved in MongoDB only, skipping Neo4j due to missing required fields", entity.Id)
 		return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityUpdate] missing required fields for Neo4j entity update")
 	}
 
-	log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Updating existing entity in Neo4j: %s", entity.Id)
+	logger.Infof("[neo4j_handler.HandleGraphEntityUpdate] Updating existing entity in Neo4j: %s", entity.Id)
 
 	// Prepare data for Neo4j with safety checks
 	entityMap := map[string]interface{}{
@@ -260,7 +357,7 @@ func (repo *Neo4jRepository) HandleGraphEntityUpdate(ctx context.Context, entity
 		var stringValue wrapperspb.StringValue
 		err := entity.Name.GetValue().UnmarshalTo(&stringValue)
 		if err != nil {
-			log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Error unpacking Name value for entity %s: %v", entity.Id, err)
+			logger.Errorf("[neo4j_handler.HandleGraphEntityUpdate] Error unpacking Name value for entity %s: %v", entity.Id, err)
 			return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityUpdate] error unpacking Name value: %v", err)
 		}
 		// Get the actual string value from the StringValue
@@ -279,22 +376,31 @@ func (repo *Neo4jRepository) HandleGraphEntityUpdate(ctx context.Context, entity
 	// Update the entity
 	result, err := repo.UpdateGraphEntity(ctx, entity.Id, entityMap)
 	if err != nil {
-		log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Error updating entity in Neo4j: %v", err)
+		logger.Errorf("[neo4j_handler.HandleGraphEntityUpdate] Error updating entity in Neo4j: %v", err)
 		return false, err
 	} else {
-		log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Successfully updated entity in Neo4j: %s", entity.Id)
+		logger.Infof("[neo4j_handler.HandleGraphEntityUpdate] Successfully updated entity in Neo4j: %s", entity.Id)
 		return result != nil, nil // Success if we got a non-nil result
 	}
 }
 
-// HandleGraphRelationshipsCreate handles creating new relationships
+// HandleGraphRelationshipsCreate handles creating new relationships.
+// The relationships are created in one transaction, so either all of them are stored or none are.
 func (repo *Neo4jRepository) HandleGraphRelationshipsCreate(ctx context.Context, entity *pb.Entity) error {
+	return repo.ExecuteInTransaction(ctx, func(tx neo4j.ManagedTransaction) error {
+		return repo.handleGraphRelationshipsCreateTx(ctx, tx, entity)
+	})
+}
+
+// handleGraphRelationshipsCreateTx creates the relationships of an entity within tx
+func (repo *Neo4jRepository) handleGraphRelationshipsCreateTx(ctx context.Context, tx neo4j.ManagedTransaction, entity *pb.Entity) error {
+	logger := repo.logger.WithField("entity_id", entity.Id)
 	if len(entity.Relationships) == 0 {
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] No relationships to process for entity: %s", entity.Id)
+		logger.Debugf("[neo4j_handler.HandleGraphRelationshipsCreate] No relationships to process for entity: %s", entity.Id)
 		return nil
 	}
 
-	log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Processing %d relationships for entity: %s", len(entity.Relationships), entity.Id)
+	logger.Infof("[neo4j_handler.HandleGraphRelationshipsCreate] Processing %d relationships for entity: %s", len(entity.Relationships), entity.Id)
 
 	// First, process all child entities
 	for _, relationship := range entity.Relationships {
@@ -302,42 +408,86 @@ func (repo *Neo4jRepository) HandleGraphRelationshipsCreate(ctx context.Context,
 			continue
 		}
 
+		if err := ValidateTimeInterval(relationship.StartTime, relationship.EndTime); err != nil {
+			logger.Warnf("[neo4j_handler.HandleGraphRelationshipsCreate] Invalid time interval for relationship %s: %v", relationship.Id, err)
+			return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsCreate] invalid time interval for relationship %s: %v", relationship.Id, err)
+		}
+
 		// Check if the child entity exists
-		childEntityMap, err := repo.ReadGraphEntity(ctx, relationship.RelatedEntityId)
-		if err != nil || childEntityMap == nil {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Child entity %s does not exist in Neo4j. Make sure to create it first.",
+		childExists, err := repo.entityExistsTx(ctx, tx, relationship.RelatedEntityId)
+		if err != nil {
+			logger.Errorf("[neo4j_handler.HandleGraphRelationshipsCreate] Error checking child entity %s: %v", relationship.RelatedEntityId, err)
+			return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsCreate] error checking child entity %s: %v", relationship.RelatedEntityId, err)
+		}
+		if !childExists {
+			logger.Warnf("[neo4j_handler.HandleGraphRelationshipsCreate] Child entity %s does not exist in Neo4j. Make sure to create it first.",
 				relationship.RelatedEntityId)
 			return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsCreate] child entity %s does not exist", relationship.RelatedEntityId)
 		}
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Child entity %s exists in Neo4j", relationship.RelatedEntityId)
+		logger.Debugf("[neo4j_handler.HandleGraphRelationshipsCreate] Child entity %s exists in Neo4j", relationship.RelatedEntityId)
 
 		// Create the relationship
-		_, err = repo.CreateRelationship(ctx, entity.Id, relationship)
+		_, err = repo.createRelationshipTx(ctx, tx, entity.Id, relationship)
 		if err != nil {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Error creating relationship from %s to %s: %v",
+			logger.Errorf("[neo4j_handler.HandleGraphRelationshipsCreate] Error creating relationship from %s to %s: %v",
 				entity.Id, relationship.RelatedEntityId, err)
 			return err
 		}
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Successfully created relationship from %s to %s",
+		logger.Infof("[neo4j_handler.HandleGraphRelationshipsCreate] Successfully created relationship from %s to %s",
 			entity.Id, relationship.RelatedEntityId)
 	}
 
 	return nil
 }
 
+// HandleGraphEntityCreationWithRelationships creates an entity and its relationships in one transaction.
+// If any relationship cannot be created the entity node is rolled back as well.
+func (repo *Neo4jRepository) HandleGraphEntityCreationWithRelationships(ctx context.Context, entity *pb.Entity) (bool, error) {
+	logger := repo.logger.WithField("entity_id", entity.Id)
+	// Validate required fields for Neo4j entity creation
+	if !repo.validateGraphEntityCreation(entity) {
+		logger.Warnf("[neo4j_handler.HandleGraphEntityCreationWithRelationships] Entity %s saved in MongoDB only, skipping Neo4j due to missing required fields", entity.Id)
+		return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreationWithRelationships] missing required fields for Neo4j entity creation")
+	}
+
+	kind, entityMap, err := repo.graphEntityParams(entity)
+	if err != nil {
+		return false, err
+	}
+
+	err = repo.ExecuteInTransaction(ctx, func(tx neo4j.ManagedTransaction) error {
+		if _, err := repo.createGraphEntityTx(ctx, tx, kind, entityMap); err != nil {
+			return err
+		}
+		return repo.handleGraphRelationshipsCreateTx(ctx, tx, entity)
+	})
+	if err != nil {
+		logger.Errorf("[neo4j_handler.HandleGraphEntityCreationWithRelationships] Error creating entity and relationships in Neo4j, rolled back: %v", err)
+		return false, err
+	}
+
+	logger.Infof("[neo4j_handler.HandleGraphEntityCreationWithRelationships] Successfully created entity and %d relationships in Neo4j: %s", len(entity.Relationships), entity.Id)
+	return true, nil
+}
+
 // HandleGraphRelationshipsUpdate handles updating existing relationships
 func (repo *Neo4jRepository) HandleGraphRelationshipsUpdate(ctx context.Context, entity *pb.Entity) error {
+	logger := repo.logger.WithField("entity_id", entity.Id)
 	if len(entity.Relationships) == 0 {
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] No relationships to process for entity: %s", entity.Id)
+		logger.Debugf("[neo4j_handler.HandleGraphRelationshipsUpdate] No relationships to process for entity: %s", entity.Id)
 		return nil
 	}
 
-	log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Processing %d relationships for entity: %s", len(entity.Relationships), entity.Id)
+	logger.Infof("[neo4j_handler.HandleGraphRelationshipsUpdate] Processing %d relationships for entity: %s", len(entity.Relationships), entity.Id)
 
 	// First verify the parent entity exists
-	parentEntity, err := repo.ReadGraphEntity(ctx, entity.Id)
-	if err != nil || parentEntity == nil {
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Parent entity %s does not exist in Neo4j", entity.Id)
+	parentExists, err := repo.EntityExists(ctx, entity.Id)
+	if err != nil {
+		logger.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] Error checking parent entity %s: %v", entity.Id, err)
+		return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] error checking parent entity %s: %v", entity.Id, err)
+	}
+	if !parentExists {
+		logger.Warnf("[neo4j_handler.HandleGraphRelationshipsUpdate] Parent entity %s does not exist in Neo4j", entity.Id)
 		return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] parent entity %s does not exist", entity.Id)
 	}
 
@@ -347,13 +497,17 @@ func (repo *Neo4jRepository) HandleGraphRelationshipsUpdate(ctx context.Context,
 		}
 
 		// Check if the child entity exists
-		childEntityMap, err := repo.ReadGraphEntity(ctx, relationship.RelatedEntityId)
-		if err != nil || childEntityMap == nil {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Child entity %s does not exist in Neo4j. Make sure to create it first.",
+		childExists, err := repo.EntityExists(ctx, relationship.RelatedEntityId)
+		if err != nil {
+			logger.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] Error checking child entity %s: %v", relationship.RelatedEntityId, err)
+			return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] error checking child entity %s: %v", relationship.RelatedEntityId, err)
+		}
+		if !childExists {
+			logger.Warnf("[neo4j_handler.HandleGraphRelationshipsUpdate] Child entity %s does not exist in Neo4j. Make sure to create it first.",
 				relationship.RelatedEntityId)
 			return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] child entity %s does not exist", relationship.RelatedEntityId)
 		}
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Child entity %s exists in Neo4j", relationship.RelatedEntityId)
+		logger.Debugf("[neo4j_handler.HandleGraphRelationshipsUpdate] Child entity %s exists in Neo4j", relationship.RelatedEntityId)
 
 		// Prepare relationship data
 		relationshipData := map[string]interface{}{
@@ -371,21 +525,21 @@ func (repo *Neo4jRepository) HandleGraphRelationshipsUpdate(ctx context.Context,
 			// Try to update if we have an ID
 			_, err = repo.UpdateRelationship(ctx, relationship.Id, relationshipData)
 			if err == nil {
-				log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Successfully updated relationship %s from %s to %s",
+				logger.Infof("[neo4j_handler.HandleGraphRelationshipsUpdate] Successfully updated relationship %s from %s to %s",
 					relationship.Id, entity.Id, relationship.RelatedEntityId)
 				continue
 			}
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Failed to update relationship, attempting to create: %v", err)
+			logger.Warnf("[neo4j_handler.HandleGraphRelationshipsUpdate] Failed to update relationship, attempting to create: %v", err)
 		}
 
 		// Either no ID or update failed, try to create
 		_, createErr = repo.CreateRelationship(ctx, entity.Id, relationship)
 		if createErr != nil {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Error creating relationship from %s to %s: %v",
+			logger.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] Error creating relationship from %s to %s: %v",
 				entity.Id, relationship.RelatedEntityId, createErr)
 			return createErr
 		}
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Successfully created new relationship from %s to %s",
+		logger.Infof("[neo4j_handler.HandleGraphRelationshipsUpdate] Successfully created new relationship from %s to %s",
 			entity.Id, relationship.RelatedEntityId)
 	}
 
@@ -0,0 +1,133 @@
+package neo4jrepository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+)
+
+// DefaultCacheTTL is used when a CachedNeo4jRepository is created without a TTL
+const DefaultCacheTTL = 30 * time.Second
+
+var _ GraphRepository = (*CachedNeo4jRepository)(nil)
+
+// CachedNeo4jRepository wraps a GraphRepository and caches ReadGraphEntity results in memory.
+// Entries expire after the configured TTL and are invalidated when the entity is updated or deleted.
+type CachedNeo4jRepository struct {
+	GraphRepository
+	ttl   time.Duration
+	cache sync.Map // entity ID -> cachedEntity
+}
+
+// cachedEntity is a ReadGraphEntity result with its expiry time
+type cachedEntity struct {
+	entity    map[string]interface{}
+	expiresAt time.Time
+}
+
+// NewCachedNeo4jRepository creates a caching wrapper around repo. A non-positive ttl uses DefaultCacheTTL.
+func NewCachedNeo4jRepository(repo GraphRepository, ttl time.Duration) *CachedNeo4jRepository {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &CachedNeo4jRepository{
+		GraphRepository: repo,
+		ttl:             ttl,
+	}
+}
+
+// ReadGraphEntity returns the cached entity if it has not expired, otherwise reads it from the wrapped repository.
+// Cached entities of another tenant than the one of ctx are read through, so the wrapped repository rejects them.
+func (r *CachedNeo4jRepository) ReadGraphEntity(ctx context.Context, entityID string) (map[string]interface{}, error) {
+	if value, ok := r.cache.Load(entityID); ok {
+		cached := value.(cachedEntity)
+		if !inTenantScope(ctx, cached.entity) {
+			return r.GraphRepository.ReadGraphEntity(ctx, entityID)
+		}
+		if time.Now().Before(cached.expiresAt) {
+			return copyEntityMap(cached.entity), nil
+		}
+		r.cache.Delete(entityID)
+	}
+
+	entity, err := r.GraphRepository.ReadGraphEntity(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Store(entityID, cachedEntity{
+		entity:    copyEntityMap(entity),
+		expiresAt: time.Now().Add(r.ttl),
+	})
+	return entity, nil
+}
+
+// GetGraphEntity reads the entity through the cache
+func (r *CachedNeo4jRepository) GetGraphEntity(ctx context.Context, entityId string) (*pb.Kind, *pb.TimeBasedValue, string, string, error) {
+	entityMap, err := r.ReadGraphEntity(ctx, entityId)
+	kind, name, created, terminated := graphEntityFromMap(entityMap)
+	return kind, name, created, terminated, err
+}
+
+// UpdateGraphEntity updates the entity and invalidates its cache entry
+func (r *CachedNeo4jRepository) UpdateGraphEntity(ctx context.Context, id string, updateData map[string]interface{}) (map[string]interface{}, error) {
+	defer r.Invalidate(id)
+	return r.GraphRepository.UpdateGraphEntity(ctx, id, updateData)
+}
+
+// UpsertGraphEntity creates or updates the entity and invalidates its cache entry
+func (r *CachedNeo4jRepository) UpsertGraphEntity(ctx context.Context, kind *pb.Kind, entityMap map[string]interface{}) (map[string]interface{}, error) {
+	if id, ok := entityMap["Id"].(string); ok {
+		defer r.Invalidate(id)
+	}
+	return r.GraphRepository.UpsertGraphEntity(ctx, kind, entityMap)
+}
+
+// DeleteGraphEntity deletes the entity and invalidates its cache entry
+func (r *CachedNeo4jRepository) DeleteGraphEntity(ctx context.Context, entityID string) error {
+	defer r.Invalidate(entityID)
+	return r.GraphRepository.DeleteGraphEntity(ctx, entityID)
+}
+
+// DeleteEntityReassigning moves the entity's relationships, deletes it and invalidates its cache entry
+func (r *CachedNeo4jRepository) DeleteEntityReassigning(ctx context.Context, deleteID, newParentID, relType string) error {
+	defer r.Invalidate(deleteID)
+	return r.GraphRepository.DeleteEntityReassigning(ctx, deleteID, newParentID, relType)
+}
+
+// SoftDeleteGraphEntity soft-deletes the entity and invalidates its cache entry
+func (r *CachedNeo4jRepository) SoftDeleteGraphEntity(ctx context.Context, entityID string, terminatedAt string) error {
+	defer r.Invalidate(entityID)
+	return r.GraphRepository.SoftDeleteGraphEntity(ctx, entityID, terminatedAt)
+}
+
+// TerminateGraphEntity terminates the entity and its open relationships and invalidates its cache entry
+func (r *CachedNeo4jRepository) TerminateGraphEntity(ctx context.Context, entityID string, terminatedAt string) (map[string]interface{}, error) {
+	defer r.Invalidate(entityID)
+	return r.GraphRepository.TerminateGraphEntity(ctx, entityID, terminatedAt)
+}
+
+// HandleGraphEntityUpdate updates the entity and invalidates its cache entry
+func (r *CachedNeo4jRepository) HandleGraphEntityUpdate(ctx context.Context, entity *pb.Entity) (bool, error) {
+	defer r.Invalidate(entity.Id)
+	return r.GraphRepository.HandleGraphEntityUpdate(ctx, entity)
+}
+
+// Invalidate removes an entity from the cache
+func (r *CachedNeo4jRepository) Invalidate(entityID string) {
+	r.cache.Delete(entityID)
+}
+
+// copyEntityMap returns a shallow copy so callers cannot modify cached entries
+func copyEntityMap(entity map[string]interface{}) map[string]interface{} {
+	if entity == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(entity))
+	for key, value := range entity {
+		copied[key] = value
+	}
+	return copied
+}
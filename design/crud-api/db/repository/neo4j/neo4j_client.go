@@ -2,91 +2,286 @@ package neo4jrepository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"lk/datafoundation/crud-api/db/config"
 	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
-	"log"
+	"lk/datafoundation/crud-api/pkg/logging"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 type Neo4jRepository struct {
-	client neo4j.DriverWithContext
-	config *config.Neo4jConfig
+	client  neo4j.DriverWithContext
+	config  *config.Neo4jConfig
+	metrics MetricsRecorder
+	logger  logging.Logger
+
+	// ContextMap maps Kind.Major values and relationship names to vocabulary IRIs in JSON-LD exports
+	ContextMap map[string]string
+
+	// RelationshipRules lists the relationship types allowed between entity kinds; when empty every combination is allowed
+	RelationshipRules []RelationshipRule
+
+	// nameIndexes records the labels whose full-text Name index is known to exist
+	nameIndexes sync.Map
+
+	// sessionPool is created on first use by sessions()
+	sessionPool     *sessionPool
+	sessionPoolOnce sync.Once
 }
 
-// NewNeo4jRepository initializes a Neo4j driver
-func NewNeo4jRepository(ctx context.Context, config *config.Neo4jConfig) (*Neo4jRepository, error) {
-	client, err := neo4j.NewDriverWithContext(config.URI, neo4j.BasicAuth(config.Username, config.Password, ""))
+// GraphRepository is the set of graph operations used by the CRUD server.
+// Neo4jRepository implements it, so wrappers such as CachedNeo4jRepository can be swapped in.
+type GraphRepository interface {
+	Close(ctx context.Context)
+	Ping(ctx context.Context) error
+
+	CreateGraphEntity(ctx context.Context, kind *pb.Kind, entityMap map[string]interface{}) (map[string]interface{}, error)
+	ReadGraphEntity(ctx context.Context, entityID string) (map[string]interface{}, error)
+	UpdateGraphEntity(ctx context.Context, id string, updateData map[string]interface{}) (map[string]interface{}, error)
+	UpsertGraphEntity(ctx context.Context, kind *pb.Kind, entityMap map[string]interface{}) (map[string]interface{}, error)
+	DeleteGraphEntity(ctx context.Context, entityID string) error
+	SoftDeleteGraphEntity(ctx context.Context, entityID string, terminatedAt string) error
+	TerminateGraphEntity(ctx context.Context, entityID string, terminatedAt string) (map[string]interface{}, error)
+	DeleteEntityReassigning(ctx context.Context, deleteID, newParentID, relType string) error
+	FilterEntities(ctx context.Context, kind *pb.Kind, filters map[string]interface{}) ([]map[string]interface{}, error)
+
+	CreateRelationship(ctx context.Context, entityID string, rel *pb.Relationship) (map[string]interface{}, error)
+	ReadRelationships(ctx context.Context, entityID string) ([]map[string]interface{}, error)
+	ReadRelationship(ctx context.Context, relationshipID string) (map[string]interface{}, error)
+	UpdateRelationship(ctx context.Context, relationshipID string, updateData map[string]interface{}) (map[string]interface{}, error)
+	DeleteRelationship(ctx context.Context, relationshipID string) error
+	CopyRelationships(ctx context.Context, sourceID, targetID string) (int, error)
+
+	GetGraphEntity(ctx context.Context, entityId string) (*pb.Kind, *pb.TimeBasedValue, string, string, error)
+	GetGraphRelationships(ctx context.Context, entityId string) ([]*pb.RelationshipWithDirection, error)
+	GetGraphRelationshipsAsOf(ctx context.Context, entityId string, asOf string) (map[string]*pb.Relationship, error)
+	GetRelationshipsByName(ctx context.Context, entityId string, relationship string, ts string) (map[string]*pb.Relationship, error)
+	HandleGraphEntityCreation(ctx context.Context, entity *pb.Entity) (bool, error)
+	HandleGraphEntityCreationWithRelationships(ctx context.Context, entity *pb.Entity) (bool, error)
+	HandleGraphEntityUpdate(ctx context.Context, entity *pb.Entity) (bool, error)
+	HandleGraphRelationshipsCreate(ctx context.Context, entity *pb.Entity) error
+	HandleGraphRelationshipsUpdate(ctx context.Context, entity *pb.Entity) error
+}
+
+// Connection pool defaults used when the corresponding Neo4jConfig field is zero
+const (
+	DefaultMaxConnectionPoolSize        = 50
+	DefaultMaxConnectionLifetime        = 1 * time.Hour
+	DefaultConnectionAcquisitionTimeout = 60 * time.Second
+	DefaultSocketConnectTimeout         = 5 * time.Second
+)
+
+// NewNeo4jRepository initializes a Neo4j driver. A nil logger writes to stderr at the INFO level.
+func NewNeo4jRepository(ctx context.Context, config *config.Neo4jConfig, logger logging.Logger) (*Neo4jRepository, error) {
+	if logger == nil {
+		logger = logging.Default()
+	}
+	if err := validateTenantIsolationMode(config.TenantIsolationMode); err != nil {
+		logger.Errorf("[neo4j_client.NewNeo4jRepository] %v", err)
+		return nil, err
+	}
+	client, err := neo4j.NewDriverWithContext(config.URI, neo4j.BasicAuth(config.Username, config.Password, ""), poolConfig(config))
 	if err != nil {
-		log.Printf("[neo4j_client.NewNeo4jRepository] failed to create Neo4j driver: %v", err)
+		logger.Errorf("[neo4j_client.NewNeo4jRepository] failed to create Neo4j driver: %v", err)
 		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
 	}
 
 	// Verify connectivity
 	if err := client.VerifyConnectivity(ctx); err != nil {
 		client.Close(ctx) // Close if connectivity check fails
-		log.Printf("[neo4j_client.NewNeo4jRepository] failed to connect to Neo4j: %v", err)
+		logger.Errorf("[neo4j_client.NewNeo4jRepository] failed to connect to Neo4j: %v", err)
 		return nil, fmt.Errorf("failed to connect to Neo4j: %w", err)
 	}
 
-	log.Println("[neo4j_client.NewNeo4jRepository] Connected to Neo4j successfully!")
+	logger.Infof("[neo4j_client.NewNeo4jRepository] Connected to Neo4j successfully!")
 
-	return &Neo4jRepository{
+	repository := &Neo4jRepository{
 		client: client,
 		config: config,
-	}, nil
+		logger: logger,
+	}
+
+	// Index creation is idempotent; a failure only affects name searches, so it does not stop startup
+	if err := repository.EnsureIndexes(ctx); err != nil {
+		logger.Errorf("[neo4j_client.NewNeo4jRepository] failed to ensure indexes: %v", err)
+	}
+
+	return repository, nil
+}
+
+// poolConfig applies the connection pool settings of the config to the driver, using defaults for zero values
+func poolConfig(cfg *config.Neo4jConfig) func(*neo4j.Config) {
+	return func(driverConfig *neo4j.Config) {
+		driverConfig.MaxConnectionPoolSize = DefaultMaxConnectionPoolSize
+		if cfg.MaxConnectionPoolSize > 0 {
+			driverConfig.MaxConnectionPoolSize = cfg.MaxConnectionPoolSize
+		}
+
+		driverConfig.MaxConnectionLifetime = DefaultMaxConnectionLifetime
+		if cfg.MaxConnectionLifetime > 0 {
+			driverConfig.MaxConnectionLifetime = cfg.MaxConnectionLifetime
+		}
+
+		driverConfig.ConnectionAcquisitionTimeout = DefaultConnectionAcquisitionTimeout
+		if cfg.ConnectionAcquisitionTimeout > 0 {
+			driverConfig.ConnectionAcquisitionTimeout = cfg.ConnectionAcquisitionTimeout
+		}
+
+		driverConfig.SocketConnectTimeout = DefaultSocketConnectTimeout
+		if cfg.SocketConnectTimeout > 0 {
+			driverConfig.SocketConnectTimeout = cfg.SocketConnectTimeout
+		}
+	}
 }
 
 // Close properly closes the Neo4j driver
 func (r *Neo4jRepository) Close(ctx context.Context) {
 	if r.client != nil {
+		r.closeIdleSessions(ctx)
 		r.client.Close(ctx)
-		log.Println("Neo4j connection closed")
+		r.logger.Infof("Neo4j connection closed")
 	}
 }
 
-// getSession creates a new session
+// Ping verifies that the Neo4j server is reachable with the configured credentials
+func (r *Neo4jRepository) Ping(ctx context.Context) error {
+	return r.client.VerifyConnectivity(ctx)
+}
+
+// getSession acquires a session from the pool; closing it returns it to the pool.
+// If ctx is done before a session frees up, an unpooled session is returned so the caller's query reports the context error.
 func (r *Neo4jRepository) getSession(ctx context.Context) neo4j.SessionWithContext {
-	return r.client.NewSession(ctx, neo4j.SessionConfig{
-		AccessMode: neo4j.AccessModeWrite,
-	})
+	session, err := r.AcquireSession(ctx)
+	if err != nil {
+		r.logger.Warnf("[neo4j_client.getSession] no pooled session available: %v", err)
+		return r.client.NewSession(ctx, neo4j.SessionConfig{
+			AccessMode: neo4j.AccessModeWrite,
+		})
+	}
+	return session
+}
+
+// ErrQueryTimeout is returned when a query does not complete within Neo4jConfig.QueryTimeout
+var ErrQueryTimeout = errors.New("neo4j query timed out")
+
+// ErrNoPath is returned by FindShortestPath when no path connects the two entities
+var ErrNoPath = errors.New("no path between entities")
+
+// runQuery runs a query in the session, bounded by the configured query timeout.
+// With a timeout the first record is fetched before returning, so a query blocked on locks or
+// a slow scan fails here with ErrQueryTimeout instead of stalling when the caller reads the result.
+func (r *Neo4jRepository) runQuery(ctx context.Context, session neo4j.SessionWithContext, query string, params map[string]interface{}) (neo4j.ResultWithContext, error) {
+	if r.config == nil || r.config.QueryTimeout <= 0 {
+		return session.Run(ctx, query, params)
+	}
+
+	timeout := r.config.QueryTimeout
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := session.Run(timeoutCtx, query, params, neo4j.WithTxTimeout(timeout))
+	if err == nil && !result.Peek(timeoutCtx) {
+		err = result.Err()
+	}
+	if err != nil {
+		if isTimeoutError(timeoutCtx, err) {
+			return nil, fmt.Errorf("%w after %s", ErrQueryTimeout, timeout)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExecuteInTransaction runs fn in a single managed write transaction, bounded by the configured query timeout.
+// The transaction commits when fn returns nil and rolls back when it returns an error. The driver may call fn
+// again on transient failures, so fn must not have side effects outside the transaction.
+func (r *Neo4jRepository) ExecuteInTransaction(ctx context.Context, fn func(tx neo4j.ManagedTransaction) error) error {
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	var configurers []func(*neo4j.TransactionConfig)
+	if r.config != nil && r.config.QueryTimeout > 0 {
+		configurers = append(configurers, neo4j.WithTxTimeout(r.config.QueryTimeout))
+	}
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, fn(tx)
+	}, configurers...)
+	if err != nil && r.config != nil && r.config.QueryTimeout > 0 && isTimeoutError(ctx, err) {
+		return fmt.Errorf("%w after %s", ErrQueryTimeout, r.config.QueryTimeout)
+	}
+	return err
+}
+
+// isTimeoutError reports whether a query failed because the client deadline or the server transaction timeout expired
+func isTimeoutError(ctx context.Context, err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return true
+	}
+	var neo4jErr *neo4j.Neo4jError
+	return errors.As(err, &neo4jErr) && strings.Contains(neo4jErr.Code, "TransactionTimedOut")
 }
 
 // CreateGraphEntity checks if an entity exists and creates it if it doesn't
 func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind, entityMap map[string]interface{}) (map[string]interface{}, error) {
+	var createdEntity map[string]interface{}
+	err := r.ExecuteInTransaction(ctx, func(tx neo4j.ManagedTransaction) error {
+		var err error
+		createdEntity, err = r.createGraphEntityTx(ctx, tx, kind, entityMap)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return createdEntity, nil
+}
+
+// createGraphEntityTx validates and creates an entity within tx, failing if an entity with the same Id exists
+func (r *Neo4jRepository) createGraphEntityTx(ctx context.Context, tx neo4j.ManagedTransaction, kind *pb.Kind, entityMap map[string]interface{}) (map[string]interface{}, error) {
+	logger := r.logger.WithField("entity_id", entityMap["Id"])
 	// Validate the kind parameter
 	if kind == nil || kind.Major == "" {
-		log.Printf("[neo4j_client.CreateGraphEntity] missing or invalid 'Kind.Major' field")
+		logger.Warnf("[neo4j_client.CreateGraphEntity] missing or invalid 'Kind.Major' field")
 		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] missing or invalid 'Kind.Major' field")
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Kind.Major: %v", kind.Major)
+		logger.Debugf("[neo4j_client.CreateGraphEntity] Kind.Major: %v", kind.Major)
+	}
+	label, err := tenantKindLabel(ctx, kind)
+	if err != nil {
+		logger.Warnf("[neo4j_client.CreateGraphEntity] %v", err)
+		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] %v", err)
 	}
 
 	// Extract the required fields from the entityMap
 	id, ok := entityMap["Id"].(string)
 	if !ok {
-		log.Printf("[neo4j_client.CreateGraphEntity] missing or invalid 'Id' field")
+		logger.Warnf("[neo4j_client.CreateGraphEntity] missing or invalid 'Id' field")
 		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] missing or invalid 'Id' field")
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Id: %v", id)
+		logger.Debugf("[neo4j_client.CreateGraphEntity] Id: %v", id)
 	}
 
 	name, ok := entityMap["Name"].(string)
 	if !ok {
-		log.Printf("[neo4j_client.CreateGraphEntity] missing or invalid 'Name' field")
+		logger.Warnf("[neo4j_client.CreateGraphEntity] missing or invalid 'Name' field")
 		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] missing or invalid 'Name' field")
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Name: %v", name)
+		logger.Debugf("[neo4j_client.CreateGraphEntity] Name: %v", name)
 	}
 
 	created, ok := entityMap["Created"].(string)
 	if !ok {
-		log.Printf("[neo4j_client.CreateGraphEntity] missing or invalid 'Created' field")
+		logger.Warnf("[neo4j_client.CreateGraphEntity] missing or invalid 'Created' field")
 		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] missing or invalid 'Created' field")
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Created: %v", created)
+		logger.Debugf("[neo4j_client.CreateGraphEntity] Created: %v", created)
 	}
 
 	// Optional field
@@ -94,36 +289,46 @@ func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind,
 	if term, ok := entityMap["Terminated"].(string); ok {
 		terminated = &term
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Terminated: %v", terminated)
+		logger.Debugf("[neo4j_client.CreateGraphEntity] Terminated: %v", terminated)
 	}
 
-	// Open a session
-	session := r.getSession(ctx)
-	defer session.Close(ctx)
+	// Optional labels added next to the kind label, e.g. an entity that is both a Person and an Employee
+	extraLabels, err := additionalLabels(entityMap, label)
+	if err != nil {
+		logger.Warnf("[neo4j_client.CreateGraphEntity] %v", err)
+		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] %v", err)
+	}
 
-	// Check if the node already exists
-	existsQuery := `MATCH (e:` + kind.Major + ` {Id: $Id}) RETURN e`
-	result, err := session.Run(ctx, existsQuery, map[string]interface{}{"Id": id})
+	// Check if the node already exists. Ids are unique across tenants, so every tenant is searched.
+	exists, err := r.entityExistsTx(WithAllTenants(ctx), tx, id)
 	if err != nil {
-		log.Printf("[neo4j_client.CreateGraphEntity] error checking if entity exists: %v", err)
+		logger.Errorf("[neo4j_client.CreateGraphEntity] error checking if entity exists: %v", err)
 		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] error checking if entity exists: %v", err)
-	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] existsQuery: %v", existsQuery)
 	}
 
 	// If entity exists, return an error
-	if result.Next(ctx) {
-		log.Printf("[neo4j_client.CreateGraphEntity] entity with Id %s already exists", id)
+	if exists {
+		logger.Warnf("[neo4j_client.CreateGraphEntity] entity with Id %s already exists", id)
 		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] entity with Id %s already exists", id)
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] entity with Id %s does not exist", id)
+		logger.Debugf("[neo4j_client.CreateGraphEntity] entity with Id %s does not exist", id)
 	}
 
 	// Create the node
-	createQuery := `CREATE (e:` + kind.Major + ` {Id: $Id, Name: $Name, Created: datetime($Created), MinorKind: $MinorKind`
+	labels := label
+	for _, extra := range extraLabels {
+		labels += `:` + extra
+	}
+	createQuery := `CREATE (e:` + labels + ` {Id: $Id, Name: $Name, Created: datetime($Created), MinorKind: $MinorKind`
 	if terminated != nil {
 		createQuery += `, Terminated: datetime($Terminated)`
 	}
+	if kind.Tenant != "" {
+		createQuery += `, Tenant: $Tenant`
+	}
+	if len(extraLabels) > 0 {
+		createQuery += `, MajorKind: $MajorKind`
+	}
 	createQuery += `}) RETURN e`
 
 	// Set parameters for the query
@@ -136,14 +341,20 @@ func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind,
 	if terminated != nil {
 		params["Terminated"] = *terminated
 	}
+	if kind.Tenant != "" {
+		params["Tenant"] = kind.Tenant
+	}
+	if len(extraLabels) > 0 {
+		params["MajorKind"] = kind.Major
+	}
 
 	// Run the query to create the entity and return it
-	result, err = session.Run(ctx, createQuery, params)
+	result, err := r.runTx(ctx, tx, "CreateGraphEntity", createQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.CreateGraphEntity] error creating entity: %v", err)
+		logger.Errorf("[neo4j_client.CreateGraphEntity] error creating entity: %v", err)
 		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] error creating entity: %v", err)
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] created entity(run query): %v", params)
+		logger.Debugf("[neo4j_client.CreateGraphEntity] created entity(run query): %v", params)
 	}
 
 	// Retrieve the created entity
@@ -151,10 +362,10 @@ func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind,
 		createdEntity, _ := result.Record().Get("e")
 		node, ok := createdEntity.(neo4j.Node)
 		if !ok {
-			log.Printf("[neo4j_client.CreateGraphEntity] failed to cast created entity to neo4j.Node")
+			logger.Errorf("[neo4j_client.CreateGraphEntity] failed to cast created entity to neo4j.Node")
 			return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] failed to cast created entity to neo4j.Node")
 		} else {
-			log.Printf("[neo4j_client.CreateGraphEntity] created entity(retrieved-initial): %v", createdEntity)
+			logger.Debugf("[neo4j_client.CreateGraphEntity] created entity(retrieved-initial): %v", createdEntity)
 		}
 
 		// Convert the node properties to a map
@@ -178,42 +389,313 @@ func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind,
 				createdEntityMap["Terminated"] = fmt.Sprintf("%v", *terminated)
 			}
 		} else {
-			log.Printf("[neo4j_client.CreateGraphEntity] Terminated: %v", terminated)
+			logger.Debugf("[neo4j_client.CreateGraphEntity] Terminated: %v", terminated)
+		}
+		if len(extraLabels) > 0 {
+			createdEntityMap["Labels"] = node.Labels
 		}
-		log.Printf("[neo4j_client.CreateGraphEntity] created entity(retrieved-final): %v", createdEntityMap)
+		logger.Debugf("[neo4j_client.CreateGraphEntity] created entity(retrieved-final): %v", createdEntityMap)
 		return createdEntityMap, nil
 	}
 
-	log.Printf("[neo4j_client.CreateGraphEntity] failed to create entity")
+	logger.Errorf("[neo4j_client.CreateGraphEntity] failed to create entity")
 	return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] failed to create entity")
 }
 
-// CreateRelationship creates a relationship between two entities
-func (r *Neo4jRepository) CreateRelationship(ctx context.Context, entityID string, rel *pb.Relationship) (map[string]interface{}, error) {
+// EntityExists reports whether an entity with the given Id exists, without reading its properties
+func (r *Neo4jRepository) EntityExists(ctx context.Context, entityID string) (bool, error) {
+	logger := r.logger.WithField("entity_id", entityID)
+	if entityID == "" {
+		return false, fmt.Errorf("entity Id cannot be empty")
+	}
+
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
-	existsQuery := `MATCH (p {Id: $parentID}), (c {Id: $childID}) RETURN p, c`
-	result, err := session.Run(ctx, existsQuery, map[string]interface{}{
+	params := map[string]interface{}{"Id": entityID}
+	query := `MATCH (e {Id: $Id}) WHERE ` + tenantCondition(ctx, params, "e") + ` RETURN count(e) > 0 AS exists`
+	result, err := r.run(ctx, session, "EntityExists", query, params)
+	if err != nil {
+		logger.Errorf("[neo4j_client.EntityExists] error checking if entity exists: %v", err)
+		return false, fmt.Errorf("error checking if entity exists: %v", err)
+	}
+
+	if result.Next(ctx) {
+		exists, _ := result.Record().Values[0].(bool)
+		return exists, nil
+	}
+	if err := result.Err(); err != nil {
+		logger.Errorf("[neo4j_client.EntityExists] error reading result: %v", err)
+		return false, fmt.Errorf("error reading result: %v", err)
+	}
+	return false, nil
+}
+
+// entityExistsTx reports whether an entity with the given Id exists, including entities created earlier in tx
+func (r *Neo4jRepository) entityExistsTx(ctx context.Context, tx neo4j.ManagedTransaction, entityID string) (bool, error) {
+	params := map[string]interface{}{"Id": entityID}
+	query := `MATCH (e {Id: $Id}) WHERE ` + tenantCondition(ctx, params, "e") + ` RETURN count(e) > 0 AS exists`
+	result, err := r.runTx(ctx, tx, "EntityExists", query, params)
+	if err != nil {
+		return false, err
+	}
+	if result.Next(ctx) {
+		exists, _ := result.Record().Values[0].(bool)
+		return exists, nil
+	}
+	return false, result.Err()
+}
+
+// CreateGraphEntitiesBatch creates several entities of the same kind in a single session.
+// The returned slices are aligned with the input: for every index either the created entity
+// or an error is set, so one invalid or duplicate row does not abort the rest of the batch.
+func (r *Neo4jRepository) CreateGraphEntitiesBatch(ctx context.Context, kind *pb.Kind, entities []map[string]interface{}) ([]map[string]interface{}, []error) {
+	createdEntities := make([]map[string]interface{}, len(entities))
+	errs := make([]error, len(entities))
+
+	// Validate the kind parameter
+	if kind == nil || kind.Major == "" {
+		r.logger.Warnf("[neo4j_client.CreateGraphEntitiesBatch] missing or invalid 'Kind.Major' field")
+		for i := range errs {
+			errs[i] = fmt.Errorf("[neo4j_client.CreateGraphEntitiesBatch] missing or invalid 'Kind.Major' field")
+		}
+		return createdEntities, errs
+	}
+	label, err := tenantKindLabel(ctx, kind)
+	if err != nil {
+		r.logger.Warnf("[neo4j_client.CreateGraphEntitiesBatch] %v", err)
+		for i := range errs {
+			errs[i] = fmt.Errorf("[neo4j_client.CreateGraphEntitiesBatch] %v", err)
+		}
+		return createdEntities, errs
+	}
+
+	// Validate each row and collect the candidate ids
+	rowIndexById := make(map[string]int)
+	var ids []string
+	for i, entityMap := range entities {
+		id, ok := entityMap["Id"].(string)
+		if !ok || id == "" {
+			errs[i] = fmt.Errorf("[neo4j_client.CreateGraphEntitiesBatch] missing or invalid 'Id' field")
+			continue
+		}
+		if _, ok := entityMap["Name"].(string); !ok {
+			errs[i] = fmt.Errorf("[neo4j_client.CreateGraphEntitiesBatch] missing or invalid 'Name' field for entity %s", id)
+			continue
+		}
+		if _, ok := entityMap["Created"].(string); !ok {
+			errs[i] = fmt.Errorf("[neo4j_client.CreateGraphEntitiesBatch] missing or invalid 'Created' field for entity %s", id)
+			continue
+		}
+		if _, exists := rowIndexById[id]; exists {
+			errs[i] = fmt.Errorf("[neo4j_client.CreateGraphEntitiesBatch] entity with Id %s is duplicated in the batch", id)
+			continue
+		}
+		rowIndexById[id] = i
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return createdEntities, errs
+	}
+
+	createQuery := `UNWIND $batch AS row
+        CREATE (e:` + label + ` {Id: row.Id, Name: row.Name, Created: datetime(row.Created), MinorKind: $MinorKind})
+        SET e.Terminated = CASE WHEN row.Terminated IS NULL THEN NULL ELSE datetime(row.Terminated) END,
+            e.Tenant = $Tenant
+        RETURN e`
+
+	// A null Tenant leaves the property unset for shared entities
+	var tenant interface{}
+	if kind.Tenant != "" {
+		tenant = kind.Tenant
+	}
+
+	// The duplicate check and the create run in one transaction so a concurrent create cannot slip in between them.
+	// The driver may retry the transaction, so its outcome is collected afresh on every attempt.
+	var pending map[string]int
+	var rowErrs map[int]error
+	var rowEntities map[int]map[string]interface{}
+	var batchSize int
+	err = r.ExecuteInTransaction(ctx, func(tx neo4j.ManagedTransaction) error {
+		pending = make(map[string]int, len(rowIndexById))
+		for id, i := range rowIndexById {
+			pending[id] = i
+		}
+		rowErrs = make(map[int]error)
+		rowEntities = make(map[int]map[string]interface{})
+
+		// Filter out the entities that already exist. Ids are unique across kinds and tenants, so every node is searched.
+		existsParams := map[string]interface{}{"ids": ids}
+		existsQuery := `MATCH (e) WHERE e.Id IN $ids AND ` + tenantCondition(WithAllTenants(ctx), existsParams, "e") + ` RETURN e.Id AS Id`
+		result, err := r.runTx(ctx, tx, "CreateGraphEntitiesBatch", existsQuery, existsParams)
+		if err != nil {
+			return fmt.Errorf("error checking if entity exists: %v", err)
+		}
+		for result.Next(ctx) {
+			existingId, _ := result.Record().Get("Id")
+			id := fmt.Sprintf("%v", existingId)
+			if i, ok := pending[id]; ok {
+				r.logger.Warnf("[neo4j_client.CreateGraphEntitiesBatch] entity with Id %s already exists", id)
+				rowErrs[i] = fmt.Errorf("[neo4j_client.CreateGraphEntitiesBatch] entity with Id %s already exists", id)
+				delete(pending, id)
+			}
+		}
+		if err := result.Err(); err != nil {
+			return fmt.Errorf("error checking if entity exists: %v", err)
+		}
+
+		// Build the batch from the remaining rows
+		var batch []map[string]interface{}
+		for _, id := range ids {
+			i, ok := pending[id]
+			if !ok {
+				continue
+			}
+			row := map[string]interface{}{
+				"Id":         id,
+				"Name":       entities[i]["Name"],
+				"Created":    entities[i]["Created"],
+				"Terminated": nil,
+			}
+			if term, ok := entities[i]["Terminated"].(string); ok && term != "" {
+				row["Terminated"] = term
+			}
+			batch = append(batch, row)
+		}
+		batchSize = len(batch)
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		result, err = r.runTx(ctx, tx, "CreateGraphEntitiesBatch", createQuery, map[string]interface{}{
+			"batch":     batch,
+			"MinorKind": kind.Minor,
+			"Tenant":    tenant,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating entity: %v", err)
+		}
+
+		for result.Next(ctx) {
+			createdEntity, _ := result.Record().Get("e")
+			node, ok := createdEntity.(neo4j.Node)
+			if !ok {
+				r.logger.Errorf("[neo4j_client.CreateGraphEntitiesBatch] failed to cast created entity to neo4j.Node")
+				continue
+			}
+
+			id := fmt.Sprintf("%v", node.Props["Id"])
+			i, ok := pending[id]
+			if !ok {
+				continue
+			}
+
+			// Convert the node properties to a map
+			createdEntityMap := map[string]interface{}{
+				"Id":        id,
+				"Name":      fmt.Sprintf("%v", node.Props["Name"]),
+				"MinorKind": fmt.Sprintf("%v", node.Props["MinorKind"]),
+			}
+
+			// Handle date fields with proper formatting
+			if created, ok := node.Props["Created"].(time.Time); ok {
+				createdEntityMap["Created"] = created.Format(time.RFC3339)
+			} else {
+				createdEntityMap["Created"] = fmt.Sprintf("%v", node.Props["Created"])
+			}
+			if term, ok := node.Props["Terminated"].(time.Time); ok {
+				createdEntityMap["Terminated"] = term.Format(time.RFC3339)
+			}
+
+			rowEntities[i] = createdEntityMap
+			delete(pending, id)
+		}
+		return result.Err()
+	})
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.CreateGraphEntitiesBatch] %v", err)
+		for _, i := range rowIndexById {
+			errs[i] = fmt.Errorf("[neo4j_client.CreateGraphEntitiesBatch] %v", err)
+		}
+		return createdEntities, errs
+	}
+
+	for i, rowErr := range rowErrs {
+		errs[i] = rowErr
+	}
+	for i, createdEntity := range rowEntities {
+		createdEntities[i] = createdEntity
+	}
+
+	// Any row that was submitted but not returned has failed
+	for id, i := range pending {
+		errs[i] = fmt.Errorf("[neo4j_client.CreateGraphEntitiesBatch] failed to create entity %s", id)
+	}
+
+	r.logger.Infof("[neo4j_client.CreateGraphEntitiesBatch] created %d of %d entities", batchSize-len(pending), len(entities))
+	return createdEntities, errs
+}
+
+// CreateRelationship creates a relationship between two entities
+func (r *Neo4jRepository) CreateRelationship(ctx context.Context, entityID string, rel *pb.Relationship) (map[string]interface{}, error) {
+	var createdRelationship map[string]interface{}
+	err := r.ExecuteInTransaction(ctx, func(tx neo4j.ManagedTransaction) error {
+		var err error
+		createdRelationship, err = r.createRelationshipTx(ctx, tx, entityID, rel)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return createdRelationship, nil
+}
+
+// createRelationshipTx creates a relationship between two existing entities within tx, applying the relationship rules
+func (r *Neo4jRepository) createRelationshipTx(ctx context.Context, tx neo4j.ManagedTransaction, entityID string, rel *pb.Relationship) (map[string]interface{}, error) {
+	logger := r.logger.WithField("entity_id", entityID)
+	if err := ValidateTimeInterval(rel.StartTime, rel.EndTime); err != nil {
+		logger.Warnf("[neo4j_client.CreateRelationship] invalid time interval: %v", err)
+		return nil, fmt.Errorf("invalid time interval: %v", err)
+	}
+
+	// Both entities must belong to the tenant of ctx, so relationships never cross tenants
+	existsParams := map[string]interface{}{
 		"parentID": entityID,
 		"childID":  rel.RelatedEntityId,
-	})
+	}
+	existsQuery := `MATCH (p {Id: $parentID}), (c {Id: $childID})
+                    WHERE ` + tenantCondition(ctx, existsParams, "p", "c") + `
+                    RETURN ` + majorKindExpr("p") + ` AS parentMajor, p.MinorKind AS parentMinor,
+                           ` + majorKindExpr("c") + ` AS childMajor, c.MinorKind AS childMinor`
+	result, err := r.runTx(ctx, tx, "CreateRelationship", existsQuery, existsParams)
 	if err != nil {
-		log.Printf("[neo4j_client.CreateRelationship] error checking entities: %v", err)
+		logger.Errorf("[neo4j_client.CreateRelationship] error checking entities: %v", err)
 		return nil, fmt.Errorf("error checking entities: %v", err)
 	} else {
-		log.Printf("[neo4j_client.CreateRelationship] existsQuery: %v", existsQuery)
+		logger.Debugf("[neo4j_client.CreateRelationship] existsQuery: %v", existsQuery)
 	}
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.CreateRelationship] either parent or child entity does not exist")
+		logger.Warnf("[neo4j_client.CreateRelationship] either parent or child entity does not exist")
 		return nil, fmt.Errorf("either parent or child entity does not exist")
 	} else {
-		log.Printf("[neo4j_client.CreateRelationship] either parent or child entity exist")
+		logger.Debugf("[neo4j_client.CreateRelationship] either parent or child entity exist")
 	}
 
-	createQuery := `MATCH (p {Id: $parentID}), (c {Id: $childID})
-                    MERGE (p)-[r:` + rel.Name + ` {Id: $relationshipID}]->(c)
-                    SET r.Created = datetime($startDate)`
+	// Both kinds must be allowed to relate through this relationship type
+	record := result.Record()
+	kindOf := func(key string) string {
+		value, _ := record.Get(key)
+		if value == nil {
+			return ""
+		}
+		return fmt.Sprintf("%v", value)
+	}
+	if err := r.checkRelationshipRules(rel.Name, kindOf("parentMajor"), kindOf("parentMinor"), kindOf("childMajor"), kindOf("childMinor")); err != nil {
+		logger.Warnf("[neo4j_client.CreateRelationship] %v", err)
+		return nil, err
+	}
 
 	params := map[string]interface{}{
 		"parentID":       entityID,
@@ -221,64 +703,238 @@ func (r *Neo4jRepository) CreateRelationship(ctx context.Context, entityID strin
 		"relationshipID": rel.Id,
 		"startDate":      rel.StartTime,
 	}
+	createQuery := `MATCH (p {Id: $parentID}), (c {Id: $childID})
+                    WHERE ` + tenantCondition(ctx, params, "p", "c") + `
+                    MERGE (p)-[r:` + rel.Name + ` {Id: $relationshipID}]->(c)
+                    SET r.Created = datetime($startDate)`
 
 	if rel.EndTime != "" {
 		createQuery += `, r.Terminated = datetime($endDate)`
 		params["endDate"] = rel.EndTime
 	}
 
+	if len(rel.Properties) > 0 {
+		properties, err := encodeRelationshipProperties(rel.Properties)
+		if err != nil {
+			logger.Errorf("[neo4j_client.CreateRelationship] error encoding relationship properties: %v", err)
+			return nil, fmt.Errorf("error encoding relationship properties: %v", err)
+		}
+		createQuery += `, r += $properties`
+		params["properties"] = properties
+	}
+
 	createQuery += ` RETURN r`
 
-	result, err = session.Run(ctx, createQuery, params)
+	result, err = r.runTx(ctx, tx, "CreateRelationship", createQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.CreateRelationship] error creating relationship: %v", err)
+		logger.Errorf("[neo4j_client.CreateRelationship] error creating relationship: %v", err)
 		return nil, fmt.Errorf("error creating relationship: %v", err)
 	} else {
-		log.Printf("[neo4j_client.CreateRelationship] createQuery: %v", createQuery)
-		log.Printf("[neo4j_client.CreateRelationship] params: %v", params)
+		logger.Debugf("[neo4j_client.CreateRelationship] createQuery: %v", createQuery)
+		logger.Debugf("[neo4j_client.CreateRelationship] params: %v", params)
 	}
 
 	if result.Next(ctx) {
 		createdRel, _ := result.Record().Get("r")
 		relationship, ok := createdRel.(neo4j.Relationship)
 		if !ok {
-			log.Printf("[neo4j_client.CreateRelationship] failed to cast created relationship to neo4j.Relationship")
+			logger.Errorf("[neo4j_client.CreateRelationship] failed to cast created relationship to neo4j.Relationship")
 			return nil, fmt.Errorf("failed to cast created relationship to neo4j.Relationship")
 		} else {
-			log.Printf("[neo4j_client.CreateRelationship] created relationship: %v", createdRel)
+			logger.Debugf("[neo4j_client.CreateRelationship] created relationship: %v", createdRel)
 		}
 
-		relationshipMap := map[string]interface{}{
-			"Id":               fmt.Sprintf("%v", relationship.Props["Id"]),
-			"relationshipType": rel.Name,
-		}
+		relationshipMap := r.createdRelationshipMap(relationship, rel)
+		logger.Infof("[neo4j_client.CreateRelationship] created relationship: %v", relationshipMap)
+		return relationshipMap, nil
+	} else {
+		logger.Errorf("[neo4j_client.CreateRelationship] failed to retrieve created relationship: %v", result)
+	}
 
-		// Handle date fields with proper formatting
-		if created, ok := relationship.Props["Created"].(time.Time); ok {
-			relationshipMap["Created"] = created.Format(time.RFC3339)
+	return nil, fmt.Errorf("failed to retrieve created relationship")
+}
+
+// createdRelationshipMap converts a relationship returned by a create query to the map returned by CreateRelationship
+func (r *Neo4jRepository) createdRelationshipMap(relationship neo4j.Relationship, rel *pb.Relationship) map[string]interface{} {
+	relationshipMap := map[string]interface{}{
+		"Id":               fmt.Sprintf("%v", relationship.Props["Id"]),
+		"relationshipType": rel.Name,
+	}
+
+	// Handle date fields with proper formatting
+	if created, ok := relationship.Props["Created"].(time.Time); ok {
+		relationshipMap["Created"] = created.Format(time.RFC3339)
+	} else {
+		relationshipMap["Created"] = fmt.Sprintf("%v", relationship.Props["Created"])
+	}
+
+	if rel.EndTime != "" {
+		if terminated, ok := relationship.Props["Terminated"].(time.Time); ok {
+			relationshipMap["Terminated"] = terminated.Format(time.RFC3339)
 		} else {
-			relationshipMap["Created"] = fmt.Sprintf("%v", relationship.Props["Created"])
+			relationshipMap["Terminated"] = fmt.Sprintf("%v", relationship.Props["Terminated"])
 		}
+	}
 
-		if rel.EndTime != "" {
-			if terminated, ok := relationship.Props["Terminated"].(time.Time); ok {
-				relationshipMap["Terminated"] = terminated.Format(time.RFC3339)
-			} else {
-				relationshipMap["Terminated"] = fmt.Sprintf("%v", relationship.Props["Terminated"])
+	if properties := r.decodeRelationshipProperties(relationship.Props); len(properties) > 0 {
+		relationshipMap["properties"] = properties
+	}
+	return relationshipMap
+}
+
+// CreateRelationshipsBatch creates several outgoing relationships of parentID in one transaction.
+// The Ids of all referenced entities are checked in a single query, then the valid relationships are created
+// with one UNWIND statement per relationship type. The returned slices are aligned with rels: for every index
+// either the created relationship or an error is set, so a missing child does not abort the rest of the batch.
+func (r *Neo4jRepository) CreateRelationshipsBatch(ctx context.Context, parentID string, rels []*pb.Relationship) ([]map[string]interface{}, []error) {
+	logger := r.logger.WithField("entity_id", parentID)
+	created := make([]map[string]interface{}, len(rels))
+	errs := make([]error, len(rels))
+
+	// Validate each relationship and collect the referenced ids. Invalid dates are caught here, since datetime()
+	// failing on one row would abort the whole transaction.
+	ids := []string{parentID}
+	seen := make(map[string]bool)
+	for i, rel := range rels {
+		switch {
+		case rel == nil || rel.Id == "" || rel.Name == "" || rel.RelatedEntityId == "":
+			errs[i] = fmt.Errorf("[neo4j_client.CreateRelationshipsBatch] relationship %d must have an Id, Name and RelatedEntityId", i)
+		case seen[rel.Id]:
+			errs[i] = fmt.Errorf("[neo4j_client.CreateRelationshipsBatch] relationship with Id %s is duplicated in the batch", rel.Id)
+		case !labelPattern.MatchString(rel.Name):
+			errs[i] = fmt.Errorf("[neo4j_client.CreateRelationshipsBatch] invalid relationship type %q: only letters, digits and '_' are allowed", rel.Name)
+		default:
+			seen[rel.Id] = true
+			if _, err := parseIntervalTime(rel.StartTime); err != nil {
+				errs[i] = fmt.Errorf("[neo4j_client.CreateRelationshipsBatch] invalid start time %q for relationship %s: %v", rel.StartTime, rel.Id, err)
+				continue
 			}
+			if err := ValidateTimeInterval(rel.StartTime, rel.EndTime); err != nil {
+				errs[i] = fmt.Errorf("[neo4j_client.CreateRelationshipsBatch] invalid time interval for relationship %s: %v", rel.Id, err)
+				continue
+			}
+			ids = append(ids, rel.RelatedEntityId)
 		}
+	}
 
-		log.Printf("[neo4j_client.CreateRelationship] created relationship: %v", relationshipMap)
-		return relationshipMap, nil
-	} else {
-		log.Printf("[neo4j_client.CreateRelationship] failed to retrieve created relationship: %v", result)
+	err := r.ExecuteInTransaction(ctx, func(tx neo4j.ManagedTransaction) error {
+		// Fetch the kinds of the parent and every child of the tenant of ctx in one query
+		params := map[string]interface{}{"ids": ids}
+		result, err := r.runTx(ctx, tx, "CreateRelationshipsBatch", `
+            MATCH (e) WHERE e.Id IN $ids AND `+tenantCondition(ctx, params, "e")+`
+            RETURN e.Id AS Id, `+majorKindExpr("e")+` AS Major, e.MinorKind AS Minor`, params)
+		if err != nil {
+			return fmt.Errorf("error checking entities: %v", err)
+		}
+		kinds := make(map[string][2]string)
+		for result.Next(ctx) {
+			record := result.Record()
+			id, _ := record.Get("Id")
+			major, _ := record.Get("Major")
+			minor, _ := record.Get("Minor")
+			kinds[fmt.Sprintf("%v", id)] = [2]string{fmt.Sprintf("%v", major), fmt.Sprintf("%v", minor)}
+		}
+		if err := result.Err(); err != nil {
+			return fmt.Errorf("error checking entities: %v", err)
+		}
+		parentKind, ok := kinds[parentID]
+		if !ok {
+			return fmt.Errorf("parent entity %s does not exist", parentID)
+		}
+
+		// Group the valid relationships by type, since a type cannot be passed as a parameter
+		rowsByType := make(map[string][]map[string]interface{})
+		indexById := make(map[string]int)
+		for i, rel := range rels {
+			if errs[i] != nil {
+				continue
+			}
+			childKind, ok := kinds[rel.RelatedEntityId]
+			if !ok {
+				errs[i] = fmt.Errorf("[neo4j_client.CreateRelationshipsBatch] child entity %s does not exist", rel.RelatedEntityId)
+				continue
+			}
+			if err := r.checkRelationshipRules(rel.Name, parentKind[0], parentKind[1], childKind[0], childKind[1]); err != nil {
+				errs[i] = err
+				continue
+			}
+			properties, err := encodeRelationshipProperties(rel.Properties)
+			if err != nil {
+				errs[i] = fmt.Errorf("[neo4j_client.CreateRelationshipsBatch] error encoding properties of relationship %s: %v", rel.Id, err)
+				continue
+			}
+
+			row := map[string]interface{}{
+				"Id":         rel.Id,
+				"childID":    rel.RelatedEntityId,
+				"startDate":  rel.StartTime,
+				"endDate":    nil,
+				"properties": properties,
+			}
+			if rel.EndTime != "" {
+				row["endDate"] = rel.EndTime
+			}
+			rowsByType[rel.Name] = append(rowsByType[rel.Name], row)
+			indexById[rel.Id] = i
+		}
+
+		for relType, rows := range rowsByType {
+			params := map[string]interface{}{
+				"parentID": parentID,
+				"rows":     rows,
+			}
+			result, err := r.runTx(ctx, tx, "CreateRelationshipsBatch", `
+                UNWIND $rows AS row
+                MATCH (p {Id: $parentID}), (c {Id: row.childID})
+                WHERE `+tenantCondition(ctx, params, "p", "c")+`
+                MERGE (p)-[r:`+relType+` {Id: row.Id}]->(c)
+                SET r.Created = datetime(row.startDate),
+                    r.Terminated = CASE WHEN row.endDate IS NULL THEN r.Terminated ELSE datetime(row.endDate) END,
+                    r += row.properties
+                RETURN r`, params)
+			if err != nil {
+				return fmt.Errorf("error creating %s relationships: %v", relType, err)
+			}
+			for result.Next(ctx) {
+				value, _ := result.Record().Get("r")
+				relationship, ok := value.(neo4j.Relationship)
+				if !ok {
+					continue
+				}
+				if i, ok := indexById[fmt.Sprintf("%v", relationship.Props["Id"])]; ok {
+					created[i] = r.createdRelationshipMap(relationship, rels[i])
+				}
+			}
+			if err := result.Err(); err != nil {
+				return fmt.Errorf("error creating %s relationships: %v", relType, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Errorf("[neo4j_client.CreateRelationshipsBatch] %v", err)
+		for i := range rels {
+			if errs[i] == nil {
+				created[i] = nil
+				errs[i] = fmt.Errorf("[neo4j_client.CreateRelationshipsBatch] %v", err)
+			}
+		}
+		return created, errs
 	}
 
-	return nil, fmt.Errorf("failed to retrieve created relationship")
+	// Any valid relationship that was not returned has failed
+	for i, rel := range rels {
+		if errs[i] == nil && created[i] == nil {
+			errs[i] = fmt.Errorf("[neo4j_client.CreateRelationshipsBatch] failed to create relationship %s", rel.Id)
+		}
+	}
+	logger.Infof("[neo4j_client.CreateRelationshipsBatch] processed %d relationships for entity %s", len(rels), parentID)
+	return created, errs
 }
 
 // ReadGraphEntity retrieves an entity by its ID from the Neo4j database and returns it as a map.
 func (r *Neo4jRepository) ReadGraphEntity(ctx context.Context, entityID string) (map[string]interface{}, error) {
+	logger := r.logger.WithField("entity_id", entityID)
 	if entityID == "" {
 		return nil, fmt.Errorf("entity Id cannot be empty")
 	}
@@ -287,18 +943,20 @@ func (r *Neo4jRepository) ReadGraphEntity(ctx context.Context, entityID string)
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
-	// Cypher query to retrieve the entity with both Major and Minor kinds
+	// Cypher query to retrieve the entity with both Major and Minor kinds, if it belongs to the tenant of ctx
+	params := map[string]interface{}{"Id": entityID}
 	query := `
-        MATCH (e {Id: $Id})
-        RETURN labels(e)[0] AS MajorKind, e.MinorKind AS MinorKind, e.Id AS Id, e.Name AS Name, 
+        MATCH (e {Id: $Id}) WHERE ` + tenantCondition(ctx, params, "e") + `
+        RETURN ` + majorKindExpr("e") + ` AS MajorKind, e.MinorKind AS MinorKind, e.Id AS Id, e.Name AS Name, 
                toString(e.Created) AS Created, 
-               CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS Terminated
+               CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS Terminated,
+               e.Tenant AS Tenant, ` + labelsExpr("e") + ` AS Labels
     `
 
 	// Run the query
-	result, err := session.Run(ctx, query, map[string]interface{}{"Id": entityID})
+	result, err := r.run(ctx, session, "ReadGraphEntity", query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.ReadGraphEntity] error querying entity: %v", err)
+		logger.Errorf("[neo4j_client.ReadGraphEntity] error querying entity: %v", err)
 		return nil, fmt.Errorf("error querying entity: %v", err)
 	}
 
@@ -311,7 +969,7 @@ func (r *Neo4jRepository) ReadGraphEntity(ctx context.Context, entityID string)
 			"Id":        fmt.Sprintf("%v", record.Values[2]), // e.Id
 			"Name":      fmt.Sprintf("%v", record.Values[3]), // e.Name
 			"Created":   fmt.Sprintf("%v", record.Values[4]), // e.Created
-			"MajorKind": fmt.Sprintf("%v", record.Values[0]), // labels(e)[0]
+			"MajorKind": fmt.Sprintf("%v", record.Values[0]), // label without the tenant prefix
 			"MinorKind": fmt.Sprintf("%v", record.Values[1]), // e.MinorKind
 		}
 
@@ -320,6 +978,16 @@ func (r *Neo4jRepository) ReadGraphEntity(ctx context.Context, entityID string)
 			entity["Terminated"] = fmt.Sprintf("%v", terminatedVal)
 		}
 
+		// Add Tenant if the entity belongs to one
+		if tenant, exists := record.Get("Tenant"); exists && tenant != nil {
+			entity["Tenant"] = fmt.Sprintf("%v", tenant)
+		}
+
+		// All node labels, including the kind label and any additional labels
+		if labels, exists := record.Get("Labels"); exists && labels != nil {
+			entity["Labels"] = stringList(labels)
+		}
+
 		return entity, nil
 	}
 
@@ -327,27 +995,157 @@ func (r *Neo4jRepository) ReadGraphEntity(ctx context.Context, entityID string)
 	return nil, fmt.Errorf("entity with Id %s not found", entityID)
 }
 
+// ReadGraphEntitiesByIds retrieves several entities in a single query and returns them keyed by Id.
+// Ids that are not found are omitted from the result. Entities are formatted as in ReadGraphEntity.
+func (r *Neo4jRepository) ReadGraphEntitiesByIds(ctx context.Context, ids []string) (map[string]map[string]interface{}, error) {
+	entities := make(map[string]map[string]interface{}, len(ids))
+	if len(ids) == 0 {
+		return entities, nil
+	}
+
+	// Open a session
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{"ids": ids}
+	query := `
+        MATCH (e) WHERE e.Id IN $ids AND ` + tenantCondition(ctx, params, "e") + `
+        RETURN ` + majorKindExpr("e") + ` AS MajorKind, e.MinorKind AS MinorKind, e.Id AS Id, e.Name AS Name, 
+               toString(e.Created) AS Created, 
+               CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS Terminated,
+               e.Tenant AS Tenant
+    `
+
+	result, err := r.run(ctx, session, "ReadGraphEntitiesByIds", query, params)
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.ReadGraphEntitiesByIds] error querying entities: %v", err)
+		return nil, fmt.Errorf("error querying entities: %v", err)
+	}
+
+	for result.Next(ctx) {
+		entity := graphEntityFromRecord(result.Record())
+		entities[entity["Id"].(string)] = entity
+	}
+
+	if err := result.Err(); err != nil {
+		r.logger.Errorf("[neo4j_client.ReadGraphEntitiesByIds] error iterating over query result: %v", err)
+		return nil, fmt.Errorf("error iterating over query result: %v", err)
+	}
+
+	return entities, nil
+}
+
+// FindEntitiesCreatedBetween retrieves entities of any kind created in the window [from, to), oldest first.
+// A positive limit caps the number of entities returned. Entities are formatted as in ReadGraphEntity.
+func (r *Neo4jRepository) FindEntitiesCreatedBetween(ctx context.Context, from, to string, limit int) ([]map[string]interface{}, error) {
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("both from and to must be provided")
+	}
+	if err := ValidateTimeInterval(from, to); err != nil {
+		return nil, err
+	}
+
+	// Open a session
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{
+		"from": from,
+		"to":   to,
+	}
+	query := `
+        MATCH (e)
+        WHERE e.Created >= datetime($from) AND e.Created < datetime($to) AND ` + tenantCondition(ctx, params, "e") + `
+        RETURN ` + majorKindExpr("e") + ` AS MajorKind, e.MinorKind AS MinorKind, e.Id AS Id, e.Name AS Name, 
+               toString(e.Created) AS Created, 
+               CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS Terminated,
+               e.Tenant AS Tenant
+        ORDER BY e.Created, e.Id
+    `
+	if limit > 0 {
+		query += `LIMIT $limit `
+		params["limit"] = limit
+	}
+
+	result, err := r.run(ctx, session, "FindEntitiesCreatedBetween", query, params)
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.FindEntitiesCreatedBetween] error querying entities: %v", err)
+		return nil, fmt.Errorf("error querying entities: %v", err)
+	}
+
+	var entities []map[string]interface{}
+	for result.Next(ctx) {
+		entities = append(entities, graphEntityFromRecord(result.Record()))
+	}
+
+	if err := result.Err(); err != nil {
+		r.logger.Errorf("[neo4j_client.FindEntitiesCreatedBetween] error iterating over query result: %v", err)
+		return nil, fmt.Errorf("error iterating over query result: %v", err)
+	}
+
+	return entities, nil
+}
+
+// graphEntityFromRecord maps a record with MajorKind, MinorKind, Id, Name, Created, Terminated and optional Tenant columns to an entity map
+func graphEntityFromRecord(record *neo4j.Record) map[string]interface{} {
+	majorKind, _ := record.Get("MajorKind")
+	minorKind, _ := record.Get("MinorKind")
+	id, _ := record.Get("Id")
+	name, _ := record.Get("Name")
+	created, _ := record.Get("Created")
+
+	entity := map[string]interface{}{
+		"Id":        fmt.Sprintf("%v", id),
+		"Name":      fmt.Sprintf("%v", name),
+		"Created":   fmt.Sprintf("%v", created),
+		"MajorKind": fmt.Sprintf("%v", majorKind),
+		"MinorKind": fmt.Sprintf("%v", minorKind),
+	}
+	if terminated, exists := record.Get("Terminated"); exists && terminated != nil {
+		entity["Terminated"] = fmt.Sprintf("%v", terminated)
+	}
+	if tenant, exists := record.Get("Tenant"); exists && tenant != nil {
+		entity["Tenant"] = fmt.Sprintf("%v", tenant)
+	}
+	return entity
+}
+
+// stringList converts a Cypher list of strings, returned by the driver as []interface{}, to a []string
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, fmt.Sprintf("%v", item))
+	}
+	return list
+}
+
 // ReadRelatedGraphEntityIds retrieves related relationships based on a given relationship type and timestamp
 func (r *Neo4jRepository) ReadRelatedGraphEntityIds(ctx context.Context, entityID string, relationship string, ts string) ([]map[string]interface{}, error) {
 	if entityID == "" {
 		return nil, fmt.Errorf("entity Id cannot be empty")
 	}
+	// The relationship type cannot be a query parameter, so it is validated and formatted into the pattern
+	if !labelPattern.MatchString(relationship) {
+		return nil, fmt.Errorf("invalid relationship type %q: only letters, digits and '_' are allowed", relationship)
+	}
 
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
+	params := map[string]interface{}{
+		"entityID": entityID,
+		"ts":       ts,
+	}
 	query := fmt.Sprintf(`
         MATCH (e {Id: $entityID})-[r:%s]->(related)
-        WHERE r.Created <= datetime($ts) AND (r.Terminated IS NULL OR r.Terminated > datetime($ts))
+        WHERE r.Created <= datetime($ts) AND (r.Terminated IS NULL OR r.Terminated > datetime($ts)) AND %s
         RETURN r.Id AS relationshipID, r.Created AS startTime, r.Terminated AS endTime, type(r) AS name, related.Id AS relatedEntityId
-    `, relationship)
+    `, relationship, tenantCondition(ctx, params, "e"))
 
-	result, err := session.Run(ctx, query, map[string]interface{}{
-		"entityID": entityID,
-		"ts":       ts,
-	})
+	result, err := r.run(ctx, session, "ReadRelatedGraphEntityIds", query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.ReadRelatedGraphEntityIds] error querying related entities: %v", err)
+		r.logger.Errorf("[neo4j_client.ReadRelatedGraphEntityIds] error querying related entities: %v", err)
 		return nil, fmt.Errorf("error querying related entities: %v", err)
 	}
 
@@ -384,57 +1182,380 @@ func (r *Neo4jRepository) ReadRelatedGraphEntityIds(ctx context.Context, entityI
 			}
 		}
 
-		// Populate the relationship map
-		relationship := map[string]interface{}{
-			"Id":              fmt.Sprintf("%v", relationshipID),
-			"StartTime":       formattedStartTime,
-			"EndTime":         formattedEndTime,
-			"Name":            fmt.Sprintf("%v", name),
-			"RelatedEntityId": fmt.Sprintf("%v", relatedEntityID),
+		// Populate the relationship map
+		relationship := map[string]interface{}{
+			"Id":              fmt.Sprintf("%v", relationshipID),
+			"StartTime":       formattedStartTime,
+			"EndTime":         formattedEndTime,
+			"Name":            fmt.Sprintf("%v", name),
+			"RelatedEntityId": fmt.Sprintf("%v", relatedEntityID),
+		}
+
+		relationships = append(relationships, relationship)
+	}
+
+	if err := result.Err(); err != nil {
+		r.logger.Errorf("[neo4j_client.ReadRelatedGraphEntityIds] error iterating over query result: %v", err)
+		return nil, fmt.Errorf("error iterating over query result: %v", err)
+	}
+
+	return relationships, nil
+}
+
+// MaxTraversalDepth is the deepest relationship chain TraverseGraph will follow
+const MaxTraversalDepth = 10
+
+// TraverseGraph follows outgoing relationships of the given type from the start entity up to maxDepth hops.
+// An empty relationship type follows relationships of any type. Each reachable entity is returned once with the shortest depth at which it was found. When ts is set, only
+// relationships active at that time are followed.
+func (r *Neo4jRepository) TraverseGraph(ctx context.Context, startEntityID string, relationshipType string, maxDepth int, ts string) ([]map[string]interface{}, error) {
+	if startEntityID == "" {
+		return nil, fmt.Errorf("entity Id cannot be empty")
+	}
+	if maxDepth < 1 || maxDepth > MaxTraversalDepth {
+		return nil, fmt.Errorf("invalid traversal depth %d: must be between 1 and %d", maxDepth, MaxTraversalDepth)
+	}
+	if relationshipType != "" && !labelPattern.MatchString(relationshipType) {
+		return nil, fmt.Errorf("invalid relationship type %q: only letters, digits and '_' are allowed", relationshipType)
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{
+		"entityID": startEntityID,
+	}
+
+	// The type and depth cannot be query parameters, so they are validated above and formatted into the pattern
+	typePattern := ""
+	if relationshipType != "" {
+		typePattern = ":" + relationshipType
+	}
+	query := fmt.Sprintf(`
+        MATCH path = (e {Id: $entityID})-[%s*1..%d]->(related)
+        WHERE related.Id <> $entityID AND %s`, typePattern, maxDepth, tenantCondition(ctx, params, "e"))
+	if ts != "" {
+		params["ts"] = ts
+		query += `
+          AND all(rel IN relationships(path) WHERE rel.Created <= datetime($ts) AND (rel.Terminated IS NULL OR rel.Terminated > datetime($ts)))`
+	}
+	query += `
+        WITH related, min(length(path)) AS depth
+        RETURN related.Id AS Id, related.Name AS Name, ` + majorKindExpr("related") + ` AS MajorKind, related.MinorKind AS MinorKind,
+               toString(related.Created) AS Created,
+               CASE WHEN related.Terminated IS NOT NULL THEN toString(related.Terminated) ELSE NULL END AS Terminated,
+               depth
+        ORDER BY depth, Id
+    `
+
+	result, err := r.run(ctx, session, "TraverseGraph", query, params)
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.TraverseGraph] error traversing graph: %v", err)
+		return nil, fmt.Errorf("error traversing graph: %v", err)
+	}
+
+	var entities []map[string]interface{}
+	for result.Next(ctx) {
+		record := result.Record()
+
+		id, _ := record.Get("Id")
+		name, _ := record.Get("Name")
+		majorKind, _ := record.Get("MajorKind")
+		minorKind, _ := record.Get("MinorKind")
+		created, _ := record.Get("Created")
+		depth, _ := record.Get("depth")
+
+		entity := map[string]interface{}{
+			"Id":        fmt.Sprintf("%v", id),
+			"Name":      fmt.Sprintf("%v", name),
+			"MajorKind": fmt.Sprintf("%v", majorKind),
+			"MinorKind": fmt.Sprintf("%v", minorKind),
+			"Created":   fmt.Sprintf("%v", created),
+			"Depth":     int(depth.(int64)),
+		}
+		if terminated, exists := record.Get("Terminated"); exists && terminated != nil {
+			entity["Terminated"] = fmt.Sprintf("%v", terminated)
+		}
+
+		entities = append(entities, entity)
+	}
+
+	if err := result.Err(); err != nil {
+		r.logger.Errorf("[neo4j_client.TraverseGraph] error iterating over query result: %v", err)
+		return nil, fmt.Errorf("error iterating over query result: %v", err)
+	}
+
+	return entities, nil
+}
+
+// FindShortestPath returns the entities along the shortest path of outgoing relationships from fromID to toID, at most
+// maxHops long, starting with fromID and ending with toID. When relationshipTypes is non-empty only relationships of
+// those types are followed. Every entity after the first carries the type of the relationship leading to it as
+// RelationshipType. ErrNoPath is returned when either entity is missing or no such path exists.
+func (r *Neo4jRepository) FindShortestPath(ctx context.Context, fromID, toID string, relationshipTypes []string, maxHops int) ([]map[string]interface{}, error) {
+	if fromID == "" || toID == "" {
+		return nil, fmt.Errorf("entity Id cannot be empty")
+	}
+	if fromID == toID {
+		return nil, fmt.Errorf("path start and end must be different entities")
+	}
+	if maxHops < 1 || maxHops > MaxTraversalDepth {
+		return nil, fmt.Errorf("invalid path length %d: must be between 1 and %d", maxHops, MaxTraversalDepth)
+	}
+	// Relationship types and the length cannot be query parameters, so they are validated and formatted into the pattern
+	for _, relType := range relationshipTypes {
+		if !labelPattern.MatchString(relType) {
+			return nil, fmt.Errorf("invalid relationship type %q: only letters, digits and '_' are allowed", relType)
+		}
+	}
+	typePattern := ""
+	if len(relationshipTypes) > 0 {
+		typePattern = ":" + strings.Join(relationshipTypes, "|")
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{"from": fromID, "to": toID}
+	query := fmt.Sprintf(`
+        MATCH (a {Id: $from}), (b {Id: $to})
+        WHERE %s
+        MATCH path = shortestPath((a)-[%s*1..%d]->(b))
+        RETURN [n IN nodes(path) | {Id: n.Id, Name: n.Name, MajorKind: %s, MinorKind: n.MinorKind,
+                                    Created: toString(n.Created), Terminated: toString(n.Terminated)}] AS nodes,
+               [rel IN relationships(path) | type(rel)] AS types
+    `, tenantCondition(ctx, params, "a", "b"), typePattern, maxHops, majorKindExpr("n"))
+
+	result, err := r.run(ctx, session, "FindShortestPath", query, params)
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.FindShortestPath] error finding path: %v", err)
+		return nil, fmt.Errorf("error finding path: %v", err)
+	}
+
+	if !result.Next(ctx) {
+		if err := result.Err(); err != nil {
+			r.logger.Errorf("[neo4j_client.FindShortestPath] error iterating over query result: %v", err)
+			return nil, fmt.Errorf("error iterating over query result: %v", err)
+		}
+		return nil, fmt.Errorf("%w: %s to %s within %d hops", ErrNoPath, fromID, toID, maxHops)
+	}
+
+	record := result.Record()
+	nodesValue, _ := record.Get("nodes")
+	typesValue, _ := record.Get("types")
+	nodes, _ := nodesValue.([]interface{})
+	types := stringList(typesValue)
+
+	path := make([]map[string]interface{}, 0, len(nodes))
+	for i, value := range nodes {
+		node, _ := value.(map[string]interface{})
+		entity := map[string]interface{}{
+			"Id":        fmt.Sprintf("%v", node["Id"]),
+			"Name":      fmt.Sprintf("%v", node["Name"]),
+			"MajorKind": fmt.Sprintf("%v", node["MajorKind"]),
+			"MinorKind": fmt.Sprintf("%v", node["MinorKind"]),
+			"Created":   fmt.Sprintf("%v", node["Created"]),
+		}
+		if node["Terminated"] != nil {
+			entity["Terminated"] = fmt.Sprintf("%v", node["Terminated"])
+		}
+		if i > 0 && i-1 < len(types) {
+			entity["RelationshipType"] = types[i-1]
+		}
+		path = append(path, entity)
+	}
+	return path, nil
+}
+
+// DefaultMaxNeighborhoodDepth is the deepest neighborhood ReadNeighborhood reads when Neo4jConfig.MaxNeighborhoodDepth is zero
+const DefaultMaxNeighborhoodDepth = 3
+
+// maxNeighborhoodDepth returns the configured neighborhood depth limit, falling back to DefaultMaxNeighborhoodDepth
+func (r *Neo4jRepository) maxNeighborhoodDepth() int {
+	if r.config != nil && r.config.MaxNeighborhoodDepth > 0 {
+		return r.config.MaxNeighborhoodDepth
+	}
+	return DefaultMaxNeighborhoodDepth
+}
+
+// ReadNeighborhood returns the entity with every entity reachable from it within depth relationships, in either
+// direction, together with the relationships along those paths. Each node and relationship is returned once.
+func (r *Neo4jRepository) ReadNeighborhood(ctx context.Context, entityID string, depth int) ([]map[string]interface{}, []map[string]interface{}, error) {
+	if entityID == "" {
+		return nil, nil, fmt.Errorf("entity Id cannot be empty")
+	}
+	maxDepth := r.maxNeighborhoodDepth()
+	if depth < 1 || depth > maxDepth {
+		return nil, nil, fmt.Errorf("invalid neighborhood depth %d: must be between 1 and %d", depth, maxDepth)
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	// The depth cannot be a query parameter, so it is validated above and formatted into the pattern
+	params := map[string]interface{}{"id": entityID}
+	query := fmt.Sprintf(`
+        MATCH (e {Id: $id}) WHERE %s
+        OPTIONAL MATCH p = (e)-[*1..%d]-(n)
+        WITH e, coalesce(nodes(p), [e]) AS pathNodes, coalesce(relationships(p), []) AS pathRels
+        RETURN [x IN pathNodes | {Id: x.Id, Name: x.Name, MajorKind: %s, MinorKind: x.MinorKind,
+                                  Created: toString(x.Created), Terminated: toString(x.Terminated)}] AS nodes,
+               [rel IN pathRels | {Id: rel.Id, Name: type(rel), StartEntityId: startNode(rel).Id,
+                                   EndEntityId: endNode(rel).Id, Created: toString(rel.Created),
+                                   Terminated: toString(rel.Terminated)}] AS relationships
+    `, tenantCondition(ctx, params, "e"), depth, majorKindExpr("x"))
+
+	result, err := r.run(ctx, session, "ReadNeighborhood", query, params)
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.ReadNeighborhood] error reading neighborhood: %v", err)
+		return nil, nil, fmt.Errorf("error reading neighborhood: %v", err)
+	}
+
+	// Paths share nodes and relationships, so both are deduplicated on Id keeping the first occurrence
+	var nodes, relationships []map[string]interface{}
+	seenNodes := make(map[string]bool)
+	seenRelationships := make(map[string]bool)
+	found := false
+	for result.Next(ctx) {
+		found = true
+		record := result.Record()
+		nodesValue, _ := record.Get("nodes")
+		relsValue, _ := record.Get("relationships")
+
+		pathNodes, _ := nodesValue.([]interface{})
+		for _, value := range pathNodes {
+			node, _ := value.(map[string]interface{})
+			id := fmt.Sprintf("%v", node["Id"])
+			if seenNodes[id] {
+				continue
+			}
+			seenNodes[id] = true
+			nodes = append(nodes, neighborhoodMap(node, "Id", "Name", "MajorKind", "MinorKind", "Created"))
+		}
+
+		pathRels, _ := relsValue.([]interface{})
+		for _, value := range pathRels {
+			rel, _ := value.(map[string]interface{})
+			id := fmt.Sprintf("%v", rel["Id"])
+			if seenRelationships[id] {
+				continue
+			}
+			seenRelationships[id] = true
+			relationships = append(relationships, neighborhoodMap(rel, "Id", "Name", "StartEntityId", "EndEntityId", "Created"))
 		}
-
-		relationships = append(relationships, relationship)
 	}
-
 	if err := result.Err(); err != nil {
-		log.Printf("[neo4j_client.ReadRelatedGraphEntityIds] error iterating over query result: %v", err)
-		return nil, fmt.Errorf("error iterating over query result: %v", err)
+		r.logger.Errorf("[neo4j_client.ReadNeighborhood] error iterating over query result: %v", err)
+		return nil, nil, fmt.Errorf("error iterating over query result: %v", err)
+	}
+	if !found {
+		return nil, nil, fmt.Errorf("entity with Id %s not found", entityID)
 	}
 
-	return relationships, nil
+	return nodes, relationships, nil
 }
 
+// neighborhoodMap copies the given fields of a node or relationship returned by ReadNeighborhood as strings,
+// adding Terminated only when it is set
+func neighborhoodMap(value map[string]interface{}, fields ...string) map[string]interface{} {
+	out := make(map[string]interface{}, len(fields)+1)
+	for _, field := range fields {
+		out[field] = fmt.Sprintf("%v", value[field])
+	}
+	if value["Terminated"] != nil {
+		out["Terminated"] = fmt.Sprintf("%v", value["Terminated"])
+	}
+	return out
+}
+
+// ReadRelationships retrieves the incoming and outgoing relationships of an entity.
+// Use ReadRelationshipsOfTypes with nil types to read only one direction.
 func (r *Neo4jRepository) ReadRelationships(ctx context.Context, entityID string) ([]map[string]interface{}, error) {
+	return r.readRelationships(ctx, entityID, "", nil, DirectionBoth)
+}
+
+// Direction selects which relationships of an entity are read relative to that entity
+type Direction string
+
+const (
+	DirectionBoth     Direction = "BOTH"
+	DirectionOutgoing Direction = "OUTGOING"
+	DirectionIncoming Direction = "INCOMING"
+)
+
+// ReadRelationshipsOfTypes retrieves the relationships of an entity whose type is one of the given types,
+// limited to the given direction. An empty types slice matches every type.
+func (r *Neo4jRepository) ReadRelationshipsOfTypes(ctx context.Context, entityID string, types []string, direction Direction) ([]map[string]interface{}, error) {
+	return r.readRelationships(ctx, entityID, "", types, direction)
+}
+
+// ReadRelationshipsAt retrieves the incoming and outgoing relationships of an entity that are valid at the given timestamp
+func (r *Neo4jRepository) ReadRelationshipsAt(ctx context.Context, entityID string, ts string) ([]map[string]interface{}, error) {
+	if ts == "" {
+		return nil, fmt.Errorf("timestamp cannot be empty")
+	}
+	return r.readRelationships(ctx, entityID, ts, nil, DirectionBoth)
+}
+
+// readRelationships retrieves the relationships of an entity in the given direction.
+// When ts is non-empty only the relationships that are valid at that timestamp are returned,
+// and when types is non-empty only relationships of those types are returned.
+func (r *Neo4jRepository) readRelationships(ctx context.Context, entityID string, ts string, types []string, direction Direction) ([]map[string]interface{}, error) {
 
 	if entityID == "" {
 		return nil, fmt.Errorf("entity Id cannot be empty")
 	}
+	if direction != DirectionBoth && direction != DirectionOutgoing && direction != DirectionIncoming {
+		return nil, fmt.Errorf("invalid relationship direction %q", direction)
+	}
 
 	// Open session
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
-	// Cypher query to get all relationships (incoming and outgoing)
-	query := `
+	params := map[string]interface{}{
+		"entityID": entityID,
+	}
+
+	// The tenant and the optional temporal and type filters applied to every leg of the query
+	conditions := []string{tenantCondition(ctx, params, "e")}
+	if ts != "" {
+		conditions = append(conditions, `r.Created <= datetime($ts) AND (r.Terminated IS NULL OR r.Terminated > datetime($ts))`)
+		params["ts"] = ts
+	}
+	if len(types) > 0 {
+		conditions = append(conditions, `type(r) IN $types`)
+		params["types"] = types
+	}
+	filter := `WHERE ` + strings.Join(conditions, ` AND `)
+
+	// Cypher query to get the outgoing and/or incoming relationships
+	var legs []string
+	if direction != DirectionIncoming {
+		legs = append(legs, `
         MATCH (e {Id: $entityID})-[r]->(related)
+        `+filter+`
         RETURN type(r) AS type, related.Id AS relatedID, "OUTGOING" AS direction, 
                toString(r.Created) AS Created, 
                CASE WHEN r.Terminated IS NOT NULL THEN toString(r.Terminated) ELSE NULL END AS Terminated,
-               r.Id AS relationshipID
-        UNION
+               r.Id AS relationshipID, properties(r) AS properties`)
+	}
+	if direction != DirectionOutgoing {
+		legs = append(legs, `
         MATCH (e {Id: $entityID})<-[r]-(related)
+        `+filter+`
         RETURN type(r) AS type, related.Id AS relatedID, "INCOMING" AS direction, 
                toString(r.Created) AS Created, 
                CASE WHEN r.Terminated IS NOT NULL THEN toString(r.Terminated) ELSE NULL END AS Terminated,
-               r.Id AS relationshipID
-    `
+               r.Id AS relationshipID, properties(r) AS properties`)
+	}
+	query := strings.Join(legs, `
+        UNION`)
 
 	// Run the query
-	result, err := session.Run(ctx, query, map[string]interface{}{
-		"entityID": entityID,
-	})
+	result, err := r.run(ctx, session, "ReadRelationships", query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.ReadRelationships] error querying relationships: %v", err)
+		r.logger.Errorf("[neo4j_client.ReadRelationships] error querying relationships: %v", err)
 		return nil, fmt.Errorf("error querying relationships: %v", err)
 	}
 
@@ -463,6 +1584,15 @@ func (r *Neo4jRepository) ReadRelationships(ctx context.Context, entityID string
 			rel["Terminated"] = fmt.Sprintf("%v", values[4])
 		}
 
+		// Optional typed properties
+		if len(values) > 6 {
+			if props, ok := values[6].(map[string]interface{}); ok {
+				if properties := r.decodeRelationshipProperties(props); len(properties) > 0 {
+					rel["properties"] = properties
+				}
+			}
+		}
+
 		relationships = append(relationships, rel)
 	}
 
@@ -479,22 +1609,23 @@ func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID s
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
-	// Cypher query to find the relationship by its ID
+	// Cypher query to find the relationship by its ID between entities of the tenant of ctx
+	params := map[string]interface{}{
+		"relationshipID": relationshipID,
+	}
 	query := `
-        MATCH ()-[r]->()
-        WHERE r.Id = $relationshipID
+        MATCH (s)-[r]->(t)
+        WHERE r.Id = $relationshipID AND ` + tenantCondition(ctx, params, "s", "t") + `
         RETURN type(r) AS type, startNode(r).Id AS startEntityID, endNode(r).Id AS endEntityID, 
                toString(r.Created) AS Created, 
                CASE WHEN r.Terminated IS NOT NULL THEN toString(r.Terminated) ELSE NULL END AS Terminated, 
-               r.Id AS relationshipID
+               r.Id AS relationshipID, properties(r) AS properties
     `
 
 	// Run the query to fetch the relationship
-	result, err := session.Run(ctx, query, map[string]interface{}{
-		"relationshipID": relationshipID,
-	})
+	result, err := r.run(ctx, session, "ReadRelationship", query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.ReadRelationship] error querying relationship: %v", err)
+		r.logger.Errorf("[neo4j_client.ReadRelationship] error querying relationship: %v", err)
 		return nil, fmt.Errorf("error querying relationship: %v", err)
 	}
 
@@ -505,7 +1636,7 @@ func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID s
 
 		// Ensure expected values exist
 		if len(values) < 6 {
-			log.Printf("[neo4j_client.ReadRelationship] unexpected data format for relationship")
+			r.logger.Errorf("[neo4j_client.ReadRelationship] unexpected data format for relationship")
 			return nil, fmt.Errorf("unexpected data format for relationship")
 		}
 
@@ -523,6 +1654,15 @@ func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID s
 			relationship["Terminated"] = fmt.Sprintf("%v", values[4])
 		}
 
+		// Optional typed properties
+		if len(values) > 6 {
+			if props, ok := values[6].(map[string]interface{}); ok {
+				if properties := r.decodeRelationshipProperties(props); len(properties) > 0 {
+					relationship["properties"] = properties
+				}
+			}
+		}
+
 		// Return the relationship data as a map
 		return relationship, nil
 	}
@@ -533,6 +1673,7 @@ func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID s
 
 // UpdateGraphEntity updates the properties of an existing entity
 func (r *Neo4jRepository) UpdateGraphEntity(ctx context.Context, id string, updateData map[string]interface{}) (map[string]interface{}, error) {
+	logger := r.logger.WithField("entity_id", id)
 	if id == "" {
 		return nil, fmt.Errorf("entity Id cannot be empty")
 	}
@@ -546,22 +1687,23 @@ func (r *Neo4jRepository) UpdateGraphEntity(ctx context.Context, id string, upda
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
-	// Check if the entity exists
-	existsQuery := `MATCH (e {Id: $Id}) RETURN e`
-	result, err := session.Run(ctx, existsQuery, params)
+	// Check if the entity exists in the tenant of ctx
+	tenantFilter := tenantCondition(ctx, params, "e")
+	existsQuery := `MATCH (e {Id: $Id}) WHERE ` + tenantFilter + ` RETURN e`
+	result, err := r.run(ctx, session, "UpdateGraphEntity", existsQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.UpdateGraphEntity] error checking if entity exists: %v", err)
+		logger.Errorf("[neo4j_client.UpdateGraphEntity] error checking if entity exists: %v", err)
 		return nil, fmt.Errorf("error checking if entity exists: %v", err)
 	}
 
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.UpdateGraphEntity] entity with Id %s does not exist", id)
+		logger.Warnf("[neo4j_client.UpdateGraphEntity] entity with Id %s does not exist", id)
 		return nil, fmt.Errorf("entity with Id %s does not exist", id)
 	}
 
 	// Build Cypher query for updating entity
 	query := `
-        MATCH (e {Id: $Id})
+        MATCH (e {Id: $Id}) WHERE ` + tenantFilter + `
     `
 
 	// Add `Name` if provided
@@ -579,9 +1721,9 @@ func (r *Neo4jRepository) UpdateGraphEntity(ctx context.Context, id string, upda
 	// Execute update query and return updated entity
 	query += ` RETURN e`
 
-	result, err = session.Run(ctx, query, params)
+	result, err = r.run(ctx, session, "UpdateGraphEntity", query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.UpdateGraphEntity] error updating entity: %v", err)
+		logger.Errorf("[neo4j_client.UpdateGraphEntity] error updating entity: %v", err)
 		return nil, fmt.Errorf("error updating entity: %v", err)
 	}
 
@@ -589,7 +1731,7 @@ func (r *Neo4jRepository) UpdateGraphEntity(ctx context.Context, id string, upda
 	if result.Next(ctx) {
 		node, ok := result.Record().Get("e")
 		if !ok {
-			log.Printf("[neo4j_client.UpdateGraphEntity] unexpected error retrieving entity")
+			logger.Errorf("[neo4j_client.UpdateGraphEntity] unexpected error retrieving entity")
 			return nil, fmt.Errorf("unexpected error retrieving entity")
 		}
 
@@ -614,10 +1756,201 @@ func (r *Neo4jRepository) UpdateGraphEntity(ctx context.Context, id string, upda
 	return nil, fmt.Errorf("failed to retrieve updated entity")
 }
 
+// TerminateGraphEntity soft-deletes an entity by stamping its Terminated date and the Terminated date of every outgoing
+// relationship that is still open. Relationships that were already terminated keep their date. The node is kept so
+// that its history can still be read, and the updated entity is returned as in ReadGraphEntity.
+func (r *Neo4jRepository) TerminateGraphEntity(ctx context.Context, entityID string, terminatedAt string) (map[string]interface{}, error) {
+	logger := r.logger.WithField("entity_id", entityID)
+	if entityID == "" {
+		return nil, fmt.Errorf("entity Id cannot be empty")
+	}
+	if _, err := parseIntervalTime(terminatedAt); err != nil {
+		return nil, fmt.Errorf("invalid termination date %q: %v", terminatedAt, err)
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{
+		"Id":         entityID,
+		"Terminated": terminatedAt,
+	}
+	query := `
+        MATCH (e {Id: $Id}) WHERE ` + tenantCondition(ctx, params, "e") + `
+        SET e.Terminated = datetime($Terminated)
+        WITH e
+        OPTIONAL MATCH (e)-[r]->()
+        WHERE r.Terminated IS NULL
+        SET r.Terminated = datetime($Terminated)
+        WITH e, count(r) AS terminatedRelationships
+        RETURN ` + majorKindExpr("e") + ` AS MajorKind, e.MinorKind AS MinorKind, e.Id AS Id, e.Name AS Name,
+               toString(e.Created) AS Created, toString(e.Terminated) AS Terminated, e.Tenant AS Tenant, terminatedRelationships
+    `
+	result, err := r.run(ctx, session, "TerminateGraphEntity", query, params)
+	if err != nil {
+		logger.Errorf("[neo4j_client.TerminateGraphEntity] error terminating entity: %v", err)
+		return nil, fmt.Errorf("error terminating entity: %v", err)
+	}
+
+	if result.Next(ctx) {
+		record := result.Record()
+		count, _ := record.Get("terminatedRelationships")
+		logger.Infof("[neo4j_client.TerminateGraphEntity] terminated entity %s and %v open relationships", entityID, count)
+		return graphEntityFromRecord(record), nil
+	}
+
+	return nil, fmt.Errorf("entity with Id %s not found", entityID)
+}
+
+// TerminatedLabel is added to entities whose Terminated date has passed, so they can be matched by label
+const TerminatedLabel = "Terminated"
+
+// LabelExpiredEntities adds the TerminatedLabel to every entity whose Terminated date is in the past and
+// that does not carry the label yet. It returns the number of entities labelled.
+func (r *Neo4jRepository) LabelExpiredEntities(ctx context.Context) (int, error) {
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	query := `
+        MATCH (e)
+        WHERE e.Terminated IS NOT NULL AND e.Terminated < datetime() AND NOT e:` + TerminatedLabel + `
+        SET e:` + TerminatedLabel + `
+        RETURN count(e) AS labelled
+    `
+	result, err := r.run(ctx, session, "LabelExpiredEntities", query, nil)
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.LabelExpiredEntities] error labelling expired entities: %v", err)
+		return 0, fmt.Errorf("error labelling expired entities: %v", err)
+	}
+
+	labelled := 0
+	if result.Next(ctx) {
+		if count, ok := result.Record().Values[0].(int64); ok {
+			labelled = int(count)
+		}
+	}
+	if err := result.Err(); err != nil {
+		r.logger.Errorf("[neo4j_client.LabelExpiredEntities] error reading result: %v", err)
+		return 0, fmt.Errorf("error reading result: %v", err)
+	}
+
+	return labelled, nil
+}
+
+// UpsertGraphEntity creates the entity if it doesn't exist or updates it if it does, in a single query.
+// On match the Created timestamp is preserved while Name and Terminated are updated.
+func (r *Neo4jRepository) UpsertGraphEntity(ctx context.Context, kind *pb.Kind, entityMap map[string]interface{}) (map[string]interface{}, error) {
+	if kind == nil || kind.Major == "" {
+		r.logger.Warnf("[neo4j_client.UpsertGraphEntity] missing or invalid 'Kind.Major' field")
+		return nil, fmt.Errorf("[neo4j_client.UpsertGraphEntity] missing or invalid 'Kind.Major' field")
+	}
+	label, err := tenantKindLabel(ctx, kind)
+	if err != nil {
+		r.logger.Warnf("[neo4j_client.UpsertGraphEntity] %v", err)
+		return nil, fmt.Errorf("[neo4j_client.UpsertGraphEntity] %v", err)
+	}
+
+	id, ok := entityMap["Id"].(string)
+	if !ok || id == "" {
+		r.logger.Warnf("[neo4j_client.UpsertGraphEntity] missing or invalid 'Id' field")
+		return nil, fmt.Errorf("[neo4j_client.UpsertGraphEntity] missing or invalid 'Id' field")
+	}
+
+	name, ok := entityMap["Name"].(string)
+	if !ok {
+		r.logger.Warnf("[neo4j_client.UpsertGraphEntity] missing or invalid 'Name' field")
+		return nil, fmt.Errorf("[neo4j_client.UpsertGraphEntity] missing or invalid 'Name' field")
+	}
+
+	created, ok := entityMap["Created"].(string)
+	if !ok {
+		r.logger.Warnf("[neo4j_client.UpsertGraphEntity] missing or invalid 'Created' field")
+		return nil, fmt.Errorf("[neo4j_client.UpsertGraphEntity] missing or invalid 'Created' field")
+	}
+
+	params := map[string]interface{}{
+		"Id":        id,
+		"Name":      name,
+		"Created":   created,
+		"MinorKind": kind.Minor,
+	}
+
+	query := `MERGE (e:` + label + ` {Id: $Id})
+        ON CREATE SET e.Name = $Name, e.Created = datetime($Created), e.MinorKind = $MinorKind
+        ON MATCH SET e.Name = $Name`
+
+	// Tenant entities record their tenant so reads can strip the label prefix
+	if kind.Tenant != "" {
+		params["Tenant"] = kind.Tenant
+		query += `
+        SET e.Tenant = $Tenant`
+	}
+
+	// Optional field
+	if terminated, ok := entityMap["Terminated"].(string); ok && terminated != "" {
+		params["Terminated"] = terminated
+		query += `
+        SET e.Terminated = datetime($Terminated)`
+	}
+	query += `
+        RETURN e`
+
+	var upsertedEntity map[string]interface{}
+	err = r.ExecuteInTransaction(ctx, func(tx neo4j.ManagedTransaction) error {
+		// Ids are unique across kinds and tenants, so an entity with this Id under another label is not merged into
+		conflictParams := map[string]interface{}{"Id": id}
+		conflictQuery := `MATCH (e {Id: $Id}) WHERE NOT e:` + label + ` RETURN count(e) > 0 AS conflict`
+		conflictResult, err := r.runTx(ctx, tx, "UpsertGraphEntity", conflictQuery, conflictParams)
+		if err != nil {
+			return fmt.Errorf("error checking if entity exists: %v", err)
+		}
+		if conflictResult.Next(ctx) {
+			if conflict, _ := conflictResult.Record().Values[0].(bool); conflict {
+				return fmt.Errorf("entity with Id %s already exists with another kind or tenant", id)
+			}
+		} else if err := conflictResult.Err(); err != nil {
+			return fmt.Errorf("error checking if entity exists: %v", err)
+		}
+
+		result, err := r.runTx(ctx, tx, "UpsertGraphEntity", query, params)
+		if err != nil {
+			return fmt.Errorf("error upserting entity: %v", err)
+		}
+		if !result.Next(ctx) {
+			if err := result.Err(); err != nil {
+				return fmt.Errorf("error upserting entity: %v", err)
+			}
+			return fmt.Errorf("failed to upsert entity")
+		}
+
+		value, _ := result.Record().Get("e")
+		node, ok := value.(neo4j.Node)
+		if !ok {
+			return fmt.Errorf("failed to cast upserted entity to neo4j.Node")
+		}
+		upsertedEntity = make(map[string]interface{})
+		for key, value := range node.Props {
+			if timeValue, ok := value.(time.Time); ok {
+				upsertedEntity[key] = timeValue.Format(time.RFC3339)
+			} else {
+				upsertedEntity[key] = fmt.Sprintf("%v", value)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.UpsertGraphEntity] %v", err)
+		return nil, fmt.Errorf("[neo4j_client.UpsertGraphEntity] %v", err)
+	}
+
+	r.logger.Infof("[neo4j_client.UpsertGraphEntity] upserted entity: %v", upsertedEntity)
+	return upsertedEntity, nil
+}
+
 func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID string, updateData map[string]interface{}) (map[string]interface{}, error) {
 
 	if relationshipID == "" {
-		log.Printf("[neo4j_client.UpdateRelationship] relationship Id cannot be empty")
+		r.logger.Warnf("[neo4j_client.UpdateRelationship] relationship Id cannot be empty")
 		return nil, fmt.Errorf("relationship Id cannot be empty")
 	}
 
@@ -630,22 +1963,22 @@ func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
-	// Check if the relationship exists
-	existsQuery := `MATCH ()-[r {Id: $relationshipID}]->() RETURN r`
-	result, err := session.Run(ctx, existsQuery, params)
+	// Check if the relationship exists between entities of the tenant of ctx
+	match := `MATCH (s)-[r {Id: $relationshipID}]->(t) WHERE ` + tenantCondition(ctx, params, "s", "t")
+	existsQuery := match + ` RETURN r`
+	result, err := r.run(ctx, session, "UpdateRelationship", existsQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.UpdateRelationship] error checking if relationship exists: %v", err)
+		r.logger.Errorf("[neo4j_client.UpdateRelationship] error checking if relationship exists: %v", err)
 		return nil, fmt.Errorf("error checking if relationship exists: %v", err)
 	}
 
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.UpdateRelationship] relationship with Id %s does not exist", relationshipID)
+		r.logger.Warnf("[neo4j_client.UpdateRelationship] relationship with Id %s does not exist", relationshipID)
 		return nil, fmt.Errorf("relationship with Id %s does not exist", relationshipID)
 	}
 
 	// Build Cypher query for updating relationship
-	query := `
-        MATCH ()-[r {Id: $relationshipID}]->()
+	query := match + `
     `
 
 	// Add `Terminated` if provided (required)
@@ -657,9 +1990,9 @@ func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID
 	query += `SET r.Terminated = datetime($Terminated) RETURN r`
 
 	// Execute update query and return updated relationship
-	result, err = session.Run(ctx, query, params)
+	result, err = r.run(ctx, session, "UpdateRelationship", query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.UpdateRelationship] error updating relationship: %v", err)
+		r.logger.Errorf("[neo4j_client.UpdateRelationship] error updating relationship: %v", err)
 		return nil, fmt.Errorf("error updating relationship: %v", err)
 	}
 
@@ -667,7 +2000,7 @@ func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID
 	if result.Next(ctx) {
 		rel, ok := result.Record().Get("r")
 		if !ok {
-			log.Printf("[neo4j_client.UpdateRelationship] unexpected error retrieving relationship")
+			r.logger.Errorf("[neo4j_client.UpdateRelationship] unexpected error retrieving relationship")
 			return nil, fmt.Errorf("unexpected error retrieving relationship")
 		}
 
@@ -706,25 +2039,24 @@ func (r *Neo4jRepository) DeleteRelationship(ctx context.Context, relationshipID
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
-	// Check if the relationship exists
-	query := `MATCH ()-[r {Id: $relationshipID}]->() RETURN r`
-	result, err := session.Run(ctx, query, params)
+	// Check if the relationship exists between entities of the tenant of ctx
+	match := `MATCH (s)-[r {Id: $relationshipID}]->(t) WHERE ` + tenantCondition(ctx, params, "s", "t")
+	result, err := r.run(ctx, session, "DeleteRelationship", match+` RETURN r`, params)
 	if err != nil {
-		log.Printf("[neo4j_client.DeleteRelationship] error checking if relationship exists: %v", err)
+		r.logger.Errorf("[neo4j_client.DeleteRelationship] error checking if relationship exists: %v", err)
 		return fmt.Errorf("error checking if relationship exists: %v", err)
 	}
 
 	// If no relationship is found, return an error
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.DeleteRelationship] relationship with Id %s does not exist", relationshipID)
+		r.logger.Warnf("[neo4j_client.DeleteRelationship] relationship with Id %s does not exist", relationshipID)
 		return fmt.Errorf("relationship with Id %s does not exist", relationshipID)
 	}
 
 	// Delete the relationship
-	deleteQuery := `MATCH ()-[r {Id: $relationshipID}]->() DELETE r`
-	_, err = session.Run(ctx, deleteQuery, params)
+	_, err = r.run(ctx, session, "DeleteRelationship", match+` DELETE r`, params)
 	if err != nil {
-		log.Printf("[neo4j_client.DeleteRelationship] error deleting relationship: %v", err)
+		r.logger.Errorf("[neo4j_client.DeleteRelationship] error deleting relationship: %v", err)
 		return fmt.Errorf("error deleting relationship: %v", err)
 	}
 
@@ -733,67 +2065,293 @@ func (r *Neo4jRepository) DeleteRelationship(ctx context.Context, relationshipID
 
 // DeleteGraphEntity deletes an entity by its ID
 func (r *Neo4jRepository) DeleteGraphEntity(ctx context.Context, entityID string) error {
+	logger := r.logger.WithField("entity_id", entityID)
 	if entityID == "" {
-		log.Printf("[neo4j_client.DeleteGraphEntity] entity Id cannot be empty")
+		logger.Warnf("[neo4j_client.DeleteGraphEntity] entity Id cannot be empty")
 		return fmt.Errorf("entity Id cannot be empty")
 	}
 
+	// Read the relationships before taking a session, so this call never holds two pooled sessions at once
+	relationships, err := r.ReadRelationships(ctx, entityID)
+	if err != nil {
+		logger.Errorf("[neo4j_client.DeleteGraphEntity] error getting relationships: %v", err)
+		return fmt.Errorf("error getting relationships: %v", err)
+	}
+
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
-	query := `MATCH (e {Id: $entityID}) RETURN e`
 	params := map[string]interface{}{
 		"entityID": entityID,
 	}
+	match := `MATCH (e {Id: $entityID}) WHERE ` + tenantCondition(ctx, params, "e")
+	query := match + ` RETURN e`
 
-	result, err := session.Run(ctx, query, params)
+	result, err := r.run(ctx, session, "DeleteGraphEntity", query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.DeleteGraphEntity] error checking if entity exists: %v", err)
+		logger.Errorf("[neo4j_client.DeleteGraphEntity] error checking if entity exists: %v", err)
 		return fmt.Errorf("error checking if entity exists: %v", err)
 	}
 
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.DeleteGraphEntity] entity with Id %s does not exist", entityID)
+		logger.Warnf("[neo4j_client.DeleteGraphEntity] entity with Id %s does not exist", entityID)
 		return fmt.Errorf("entity with Id %s does not exist", entityID)
 	}
 
-	// Get the relationships of the entity
-	relationships, err := r.ReadRelationships(ctx, entityID)
-	if err != nil {
-		log.Printf("[neo4j_client.DeleteGraphEntity] error getting relationships: %v", err)
-		return fmt.Errorf("error getting relationships: %v", err)
-	}
-
 	// If there are relationships, return an error with relationship details
 	if len(relationships) > 0 {
-		log.Printf("[neo4j_client.DeleteGraphEntity] entity has relationships and cannot be deleted. Relationships: %v", relationships)
+		logger.Warnf("[neo4j_client.DeleteGraphEntity] entity has relationships and cannot be deleted. Relationships: %v", relationships)
 		return fmt.Errorf("entity has relationships and cannot be deleted. Relationships: %v", relationships)
 	}
 
 	// Delete the entity (node) with the given Id
-	deleteQuery := `MATCH (e {Id: $entityID}) DELETE e`
-	_, err = session.Run(ctx, deleteQuery, params)
+	_, err = r.run(ctx, session, "DeleteGraphEntity", match+` DELETE e`, params)
 	if err != nil {
-		log.Printf("[neo4j_client.DeleteGraphEntity] error deleting entity: %v", err)
+		logger.Errorf("[neo4j_client.DeleteGraphEntity] error deleting entity: %v", err)
 		return fmt.Errorf("error deleting entity: %v", err)
 	}
 
 	return nil
 }
 
+// SoftDeleteGraphEntity marks an entity as deleted by stamping its Terminated date, keeping the node and its
+// relationships. FilterEntities hides the entity once the date has passed unless include_terminated is set.
+func (r *Neo4jRepository) SoftDeleteGraphEntity(ctx context.Context, entityID string, terminatedAt string) error {
+	logger := r.logger.WithField("entity_id", entityID)
+	if entityID == "" {
+		return fmt.Errorf("entity Id cannot be empty")
+	}
+	if _, err := parseIntervalTime(terminatedAt); err != nil {
+		return fmt.Errorf("invalid termination date %q: %v", terminatedAt, err)
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{
+		"Id":         entityID,
+		"Terminated": terminatedAt,
+	}
+	query := `MATCH (e {Id: $Id}) WHERE ` + tenantCondition(ctx, params, "e") + ` SET e.Terminated = datetime($Terminated) RETURN e.Id AS Id`
+	result, err := r.run(ctx, session, "SoftDeleteGraphEntity", query, params)
+	if err != nil {
+		logger.Errorf("[neo4j_client.SoftDeleteGraphEntity] error soft-deleting entity: %v", err)
+		return fmt.Errorf("error soft-deleting entity: %v", err)
+	}
+
+	if !result.Next(ctx) {
+		if err := result.Err(); err != nil {
+			return fmt.Errorf("error soft-deleting entity: %v", err)
+		}
+		return fmt.Errorf("entity with Id %s does not exist", entityID)
+	}
+	logger.Infof("[neo4j_client.SoftDeleteGraphEntity] soft-deleted entity %s as of %s", entityID, terminatedAt)
+	return nil
+}
+
+// DeleteEntityReassigning moves the outgoing relationships of the given type from an entity to a new parent and then
+// deletes the entity, all in one transaction. The moved relationships keep their Id, dates and properties. If the entity
+// still has other relationships after the move the transaction is rolled back, as DeleteGraphEntity would refuse it.
+func (r *Neo4jRepository) DeleteEntityReassigning(ctx context.Context, deleteID, newParentID, relType string) error {
+	if deleteID == "" || newParentID == "" {
+		return fmt.Errorf("entity Id and new parent Id cannot be empty")
+	}
+	if deleteID == newParentID {
+		return fmt.Errorf("new parent must differ from the entity being deleted")
+	}
+	if relType == "" {
+		return fmt.Errorf("relationship type cannot be empty")
+	}
+	// The relationship type cannot be a query parameter, so it is validated and formatted into the pattern
+	if !labelPattern.MatchString(relType) {
+		return fmt.Errorf("invalid relationship type %q: only letters, digits and '_' are allowed", relType)
+	}
+
+	params := map[string]interface{}{
+		"deleteID":    deleteID,
+		"newParentID": newParentID,
+	}
+	// Both entities must belong to the tenant of ctx
+	matchOld := `MATCH (old {Id: $deleteID}) WHERE ` + tenantCondition(ctx, params, "old")
+	matchParent := `MATCH (parent {Id: $newParentID}) WHERE ` + tenantCondition(ctx, params, "parent")
+
+	start := time.Now()
+	err := r.ExecuteInTransaction(ctx, func(tx neo4j.ManagedTransaction) error {
+		// Both entities must exist
+		result, err := r.runTx(ctx, tx, "DeleteEntityReassigning", matchOld+` `+matchParent+` RETURN old`, params)
+		if err != nil {
+			return fmt.Errorf("error checking entities: %v", err)
+		}
+		if !result.Next(ctx) {
+			return fmt.Errorf("either entity %s or new parent %s does not exist", deleteID, newParentID)
+		}
+
+		// Recreate each relationship from the new parent and remove the old one
+		moveQuery := matchOld + `
+            MATCH (old)-[r:` + relType + `]->(child)
+            ` + matchParent + `
+            CREATE (parent)-[moved:` + relType + `]->(child)
+            SET moved = properties(r)
+            DELETE r
+            RETURN count(moved) AS moved
+        `
+		result, err = r.runTx(ctx, tx, "DeleteEntityReassigning", moveQuery, params)
+		if err != nil {
+			return fmt.Errorf("error reassigning relationships: %v", err)
+		}
+		if result.Next(ctx) {
+			moved, _ := result.Record().Get("moved")
+			r.logger.Infof("[neo4j_client.DeleteEntityReassigning] moved %v %s relationships from %s to %s", moved, relType, deleteID, newParentID)
+		}
+
+		// Any relationship left blocks the delete
+		result, err = r.runTx(ctx, tx, "DeleteEntityReassigning", matchOld+` MATCH (old)-[r]-() RETURN count(r) AS remaining`, params)
+		if err != nil {
+			return fmt.Errorf("error counting remaining relationships: %v", err)
+		}
+		if result.Next(ctx) {
+			value, _ := result.Record().Get("remaining")
+			remaining, ok := value.(int64)
+			if !ok {
+				return fmt.Errorf("unexpected remaining relationship count %v", value)
+			}
+			if remaining > 0 {
+				return fmt.Errorf("entity has %d other relationships and cannot be deleted", remaining)
+			}
+		}
+
+		if _, err := r.runTx(ctx, tx, "DeleteEntityReassigning", matchOld+` DELETE old`, params); err != nil {
+			return fmt.Errorf("error deleting entity: %v", err)
+		}
+		return nil
+	})
+	if r.metrics != nil {
+		r.metrics.ObserveQuery("DeleteEntityReassigning", time.Since(start), err)
+	}
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.DeleteEntityReassigning] %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// CopyRelationships copies the incoming and outgoing relationships of the source entity onto the target entity, in one
+// transaction. Each copy keeps the type, dates and properties of the original and gets the Id "<original Id>_<target Id>",
+// so copying twice does not duplicate relationships. Relationships between the source and the target are not copied.
+func (r *Neo4jRepository) CopyRelationships(ctx context.Context, sourceID, targetID string) (int, error) {
+	if sourceID == "" || targetID == "" {
+		return 0, fmt.Errorf("source and target entity Ids cannot be empty")
+	}
+	if sourceID == targetID {
+		return 0, fmt.Errorf("target must differ from the source entity")
+	}
+
+	params := map[string]interface{}{
+		"sourceID": sourceID,
+		"targetID": targetID,
+	}
+	// Both entities must belong to the tenant of ctx
+	matchSource := `MATCH (source {Id: $sourceID}) WHERE ` + tenantCondition(ctx, params, "source")
+	matchTarget := `MATCH (target {Id: $targetID}) WHERE ` + tenantCondition(ctx, params, "target")
+
+	start := time.Now()
+	var copied int64
+	err := r.ExecuteInTransaction(ctx, func(tx neo4j.ManagedTransaction) error {
+		// Both entities must exist
+		result, err := r.runTx(ctx, tx, "CopyRelationships", matchSource+` `+matchTarget+` RETURN source`, params)
+		if err != nil {
+			return fmt.Errorf("error checking entities: %v", err)
+		}
+		if !result.Next(ctx) {
+			return fmt.Errorf("either entity %s or target %s does not exist", sourceID, targetID)
+		}
+
+		// Relationship types can't be parameterised, so copy one type at a time
+		result, err = r.runTx(ctx, tx, "CopyRelationships", matchSource+` MATCH (source)-[r]-() RETURN DISTINCT type(r) AS type`, params)
+		if err != nil {
+			return fmt.Errorf("error reading relationship types: %v", err)
+		}
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return fmt.Errorf("error reading relationship types: %v", err)
+		}
+
+		copied = 0
+		for _, record := range records {
+			value, _ := record.Get("type")
+			relType := "`" + value.(string) + "`"
+			outgoing := matchSource + ` ` + matchTarget + `
+            MATCH (source)-[r:` + relType + `]->(other)
+            WHERE other.Id <> $targetID
+            MERGE (target)-[copy:` + relType + ` {Id: r.Id + "_" + $targetID}]->(other)
+            SET copy = properties(r), copy.Id = r.Id + "_" + $targetID
+            RETURN count(copy) AS copied
+        `
+			incoming := matchSource + ` ` + matchTarget + `
+            MATCH (source)<-[r:` + relType + `]-(other)
+            WHERE other.Id <> $targetID
+            MERGE (target)<-[copy:` + relType + ` {Id: r.Id + "_" + $targetID}]-(other)
+            SET copy = properties(r), copy.Id = r.Id + "_" + $targetID
+            RETURN count(copy) AS copied
+        `
+			for _, copyQuery := range []string{outgoing, incoming} {
+				result, err = r.runTx(ctx, tx, "CopyRelationships", copyQuery, params)
+				if err != nil {
+					return fmt.Errorf("error copying %v relationships: %v", value, err)
+				}
+				if result.Next(ctx) {
+					count, _ := result.Record().Get("copied")
+					if n, ok := count.(int64); ok {
+						copied += n
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if r.metrics != nil {
+		r.metrics.ObserveQuery("CopyRelationships", time.Since(start), err)
+	}
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.CopyRelationships] %v", err)
+		return 0, err
+	}
+
+	r.logger.Infof("[neo4j_client.CopyRelationships] copied %v relationships from %s to %s", copied, sourceID, targetID)
+	return int(copied), nil
+}
+
+// FilterEntities returns the entities of a kind matching the given filters, ordered by Id. Supported filters are id,
+// name, created and terminated for exact matches, name_contains for a full-text search on the entity name, and
+// skip and limit (ints) for paging.
 func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, filters map[string]interface{}) ([]map[string]interface{}, error) {
 	if kind == nil || kind.Major == "" {
 		return nil, fmt.Errorf("kind.Major is required")
 	}
+	label, err := tenantKindLabel(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
 
 	// Open a session
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
 	// Start building the Cypher query
-	query := `MATCH (e:` + kind.Major + `) WHERE 1=1 ` // Use kind.Major as the label
+	query := `MATCH (e:` + label + `) WHERE 1=1 ` // Use the tenant-prefixed kind.Major as the label
 	params := map[string]interface{}{}
 
+	// A name_contains filter searches the full-text Name index of the label instead of scanning it
+	if nameContains, ok := filters["name_contains"].(string); ok && strings.TrimSpace(nameContains) != "" {
+		if err := r.ensureNameIndex(ctx, session, label); err != nil {
+			return nil, err
+		}
+		query = `CALL db.index.fulltext.queryNodes($nameIndex, $nameSearch) YIELD node AS e WHERE e:` + label + ` `
+		params["nameIndex"] = nameIndexName(label)
+		params["nameSearch"] = nameSearchQuery(nameContains)
+	}
+
 	// Add MinorKind filter if provided
 	if kind.Minor != "" {
 		query += `AND e.MinorKind = $minorKind `
@@ -812,6 +2370,9 @@ func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, fil
 	if terminated, ok := filters["terminated"].(string); ok && terminated != "" {
 		query += `AND e.Terminated = datetime($terminated) `
 		params["terminated"] = terminated
+	} else if includeTerminated, _ := filters["include_terminated"].(bool); !includeTerminated {
+		// Soft-deleted entities are hidden once their Terminated date has passed
+		query += `AND (e.Terminated IS NULL OR e.Terminated > datetime()) `
 	}
 	if name, ok := filters["name"].(string); ok && name != "" {
 		query += `AND e.Name = $name `
@@ -820,17 +2381,28 @@ func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, fil
 
 	// Return the matched entities
 	query += `
-        RETURN e.Id AS id, labels(e)[0] AS kind, 
+        RETURN e.Id AS id, ` + majorKindExpr("e") + ` AS kind, 
                toString(e.Created) AS created, 
                CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS terminated, 
                e.Name AS name, 
                e.MinorKind AS minorKind
+        ORDER BY e.Id
     `
 
+	// Optional paging over the ordered results
+	if skip, ok := filters["skip"].(int); ok && skip > 0 {
+		query += `SKIP $skip `
+		params["skip"] = skip
+	}
+	if limit, ok := filters["limit"].(int); ok && limit > 0 {
+		query += `LIMIT $limit `
+		params["limit"] = limit
+	}
+
 	// Run the query
-	result, err := session.Run(ctx, query, params)
+	result, err := r.run(ctx, session, "FilterEntities", query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.FilterEntities] error querying entities: %v", err)
+		r.logger.Errorf("[neo4j_client.FilterEntities] error querying entities: %v", err)
 		return nil, fmt.Errorf("error querying entities: %v", err)
 	}
 
@@ -841,7 +2413,7 @@ func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, fil
 
 		entity := map[string]interface{}{
 			"id":         record.Values[0], // e.Id
-			"kind":       record.Values[1], // labels(e)[0]
+			"kind":       record.Values[1], // label without the tenant prefix
 			"created":    record.Values[2], // e.Created
 			"terminated": record.Values[3], // e.Terminated
 			"name":       record.Values[4], // e.Name
@@ -853,9 +2425,50 @@ func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, fil
 
 	// Check for errors during iteration
 	if err := result.Err(); err != nil {
-		log.Printf("[neo4j_client.FilterEntities] error iterating over query results: %v", err)
+		r.logger.Errorf("[neo4j_client.FilterEntities] error iterating over query results: %v", err)
 		return nil, fmt.Errorf("error iterating over query results: %v", err)
 	}
 
 	return entities, nil
 }
+
+// relationshipPropertyPrefix marks the relationship properties that hold typed values
+const relationshipPropertyPrefix = "prop_"
+
+// encodeRelationshipProperties serializes typed relationship properties so Neo4j can store them.
+// Each value is stored as the marshaled Any so its type survives the round trip.
+func encodeRelationshipProperties(properties map[string]*anypb.Any) (map[string]interface{}, error) {
+	encoded := make(map[string]interface{}, len(properties))
+	for key, value := range properties {
+		if value == nil {
+			continue
+		}
+		data, err := proto.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling property %s: %v", key, err)
+		}
+		encoded[relationshipPropertyPrefix+key] = data
+	}
+	return encoded, nil
+}
+
+// decodeRelationshipProperties extracts the typed properties from the stored relationship properties
+func (r *Neo4jRepository) decodeRelationshipProperties(props map[string]interface{}) map[string]*anypb.Any {
+	properties := make(map[string]*anypb.Any)
+	for key, value := range props {
+		if !strings.HasPrefix(key, relationshipPropertyPrefix) {
+			continue
+		}
+		data, ok := value.([]byte)
+		if !ok {
+			continue
+		}
+		decoded := &anypb.Any{}
+		if err := proto.Unmarshal(data, decoded); err != nil {
+			r.logger.Errorf("[neo4j_client.decodeRelationshipProperties] error unmarshaling property %s: %v", key, err)
+			continue
+		}
+		properties[strings.TrimPrefix(key, relationshipPropertyPrefix)] = decoded
+	}
+	return properties
+}
@@ -1,15 +1,24 @@
 package neo4jrepository
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"lk/datafoundation/crud-api/db/config"
 	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+	"lk/datafoundation/crud-api/pkg/logging"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var repository *Neo4jRepository
@@ -25,7 +34,7 @@ func TestMain(m *testing.M) {
 	}
 	log.Printf("Connecting to Neo4j at %s", cfg.URI)
 	var err error
-	repository, err = NewNeo4jRepository(ctx, cfg)
+	repository, err = NewNeo4jRepository(ctx, cfg, nil)
 	if err != nil {
 		log.Fatalf("Failed to create Neo4j repository: %v", err)
 	}
@@ -529,3 +538,1836 @@ func TestAddMinistriesAndDepartments(t *testing.T) {
 		}
 	}
 }
+
+// TestCreateGraphEntitiesBatch tests the CreateGraphEntitiesBatch method of the Neo4jRepository
+func TestCreateGraphEntitiesBatch(t *testing.T) {
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Prepare 50 entities for the batch
+	var entities []map[string]interface{}
+	for i := 1; i <= 50; i++ {
+		entities = append(entities, map[string]interface{}{
+			"Id":      fmt.Sprintf("batch-%d", i),
+			"Name":    fmt.Sprintf("Batch Person %d", i),
+			"Created": "2025-03-18T00:00:00Z",
+		})
+	}
+
+	createdEntities, errs := repository.CreateGraphEntitiesBatch(context.Background(), kind, entities)
+	assert.Equal(t, len(entities), len(createdEntities), "Expected one result per input row")
+	assert.Equal(t, len(entities), len(errs), "Expected one error slot per input row")
+
+	for i, entity := range entities {
+		assert.Nil(t, errs[i], "Expected no error when creating entity %s", entity["Id"])
+		assert.Equal(t, entity["Id"], createdEntities[i]["Id"], "Expected created entity to have the correct Id")
+		assert.Equal(t, "2025-03-18T00:00:00Z", createdEntities[i]["Created"], "Expected created entity to have the correct Created date")
+	}
+
+	// Verify that every entity is retrievable
+	for _, entity := range entities {
+		readEntity, err := repository.ReadGraphEntity(context.Background(), entity["Id"].(string))
+		assert.Nil(t, err, "Expected no error when reading entity %s", entity["Id"])
+		assert.Equal(t, entity["Name"], readEntity["Name"], "Expected entity to have the correct Name")
+		assert.Equal(t, "Person", readEntity["MajorKind"], "Expected entity to have the correct MajorKind")
+	}
+
+	// Re-submitting an existing entity alongside a new one only fails the existing row
+	_, errs = repository.CreateGraphEntitiesBatch(context.Background(), kind, []map[string]interface{}{
+		entities[0],
+		{"Id": "batch-51", "Name": "Batch Person 51", "Created": "2025-03-18T00:00:00Z"},
+	})
+	assert.NotNil(t, errs[0], "Expected an error for the already existing entity")
+	assert.Contains(t, errs[0].Error(), "already exists", "Expected error message to indicate entity already exists")
+	assert.Nil(t, errs[1], "Expected no error for the new entity")
+
+	// An Id that exists under another kind is a duplicate too
+	_, errs = repository.CreateGraphEntitiesBatch(context.Background(), &pb.Kind{Major: "Organisation", Minor: "Department"}, []map[string]interface{}{
+		{"Id": "batch-1", "Name": "Batch Organisation 1", "Created": "2025-03-18T00:00:00Z"},
+	})
+	assert.NotNil(t, errs[0], "Expected an error for an Id used by another kind")
+	assert.Contains(t, errs[0].Error(), "already exists", "Expected error message to indicate entity already exists")
+}
+
+// TestGetGraphRelationshipsAsOf tests reading the relationships of an entity at a point in time
+func TestGetGraphRelationshipsAsOf(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Create an entity and two related entities
+	for _, entity := range []map[string]interface{}{
+		{"Id": "asof-1", "Name": "Frank", "Created": "2020-01-01T00:00:00Z"},
+		{"Id": "asof-2", "Name": "Grace", "Created": "2020-01-01T00:00:00Z"},
+		{"Id": "asof-3", "Name": "Heidi", "Created": "2020-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+
+	// The first relationship ended before the second one started
+	_, err := repository.CreateRelationship(ctx, "asof-1", &pb.Relationship{
+		Id:              "asof-rel-1",
+		Name:            "KNOWS",
+		RelatedEntityId: "asof-2",
+		StartTime:       "2020-01-01T00:00:00Z",
+		EndTime:         "2022-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the first relationship")
+
+	_, err = repository.CreateRelationship(ctx, "asof-1", &pb.Relationship{
+		Id:              "asof-rel-2",
+		Name:            "KNOWS",
+		RelatedEntityId: "asof-3",
+		StartTime:       "2022-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the second relationship")
+
+	// Only the first relationship is valid in 2021
+	relationships, err := repository.GetGraphRelationshipsAsOf(ctx, "asof-1", "2021-01-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	assert.Equal(t, 1, len(relationships), "Expected exactly one relationship in 2021")
+	assert.Contains(t, relationships, "asof-rel-1")
+
+	// Only the second relationship is valid in 2023
+	relationships, err = repository.GetGraphRelationshipsAsOf(ctx, "asof-1", "2023-01-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	assert.Equal(t, 1, len(relationships), "Expected exactly one relationship in 2023")
+	assert.Contains(t, relationships, "asof-rel-2")
+
+	// Without a timestamp both relationships are returned
+	allRelationships, err := repository.GetGraphRelationships(ctx, "asof-1")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	assert.Equal(t, 2, len(allRelationships), "Expected both relationships without a timestamp")
+}
+
+// TestGetRelationshipsByName tests that GetRelationshipsByName only returns relationships of the requested type
+func TestGetRelationshipsByName(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Create four entities
+	for _, entity := range []map[string]interface{}{
+		{"Id": "byname-1", "Name": "Dave", "Created": "2023-01-01T00:00:00Z"},
+		{"Id": "byname-2", "Name": "Erin", "Created": "2023-01-01T00:00:00Z"},
+		{"Id": "byname-3", "Name": "Frank", "Created": "2023-01-01T00:00:00Z"},
+		{"Id": "byname-4", "Name": "Grace", "Created": "2023-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+
+	// Two KNOWS relationships and one LIKES relationship
+	for _, rel := range []*pb.Relationship{
+		{Id: "byname-rel-1", Name: "KNOWS", RelatedEntityId: "byname-2", StartTime: "2023-01-01T00:00:00Z"},
+		{Id: "byname-rel-2", Name: "KNOWS", RelatedEntityId: "byname-3", StartTime: "2023-01-01T00:00:00Z"},
+		{Id: "byname-rel-3", Name: "LIKES", RelatedEntityId: "byname-4", StartTime: "2023-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateRelationship(ctx, "byname-1", rel)
+		assert.Nil(t, err, "Expected no error when creating relationship %s", rel.Id)
+	}
+
+	// Only the KNOWS relationships are returned, keyed by relationship Id
+	relationships, err := repository.GetRelationshipsByName(ctx, "byname-1", "KNOWS", "2024-01-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when fetching KNOWS relationships")
+	assert.Equal(t, 2, len(relationships), "Expected both KNOWS relationships")
+	assert.Contains(t, relationships, "byname-rel-1")
+	assert.Contains(t, relationships, "byname-rel-2")
+	assert.NotContains(t, relationships, "byname-rel-3")
+	for _, rel := range relationships {
+		assert.Equal(t, "KNOWS", rel.Name)
+	}
+
+	// Nothing is valid before the relationships started
+	relationships, err = repository.GetRelationshipsByName(ctx, "byname-1", "KNOWS", "2022-01-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when fetching KNOWS relationships")
+	assert.Empty(t, relationships)
+
+	// A timestamp is required
+	_, err = repository.GetRelationshipsByName(ctx, "byname-1", "KNOWS", "")
+	assert.NotNil(t, err, "Expected an error without a timestamp")
+
+	// A relationship type that would change the query is rejected before it is run
+	malicious := "KNOWS]->(x) DETACH DELETE x //"
+	_, err = repository.GetRelationshipsByName(ctx, "byname-1", malicious, "2024-01-01T00:00:00Z")
+	assert.NotNil(t, err, "Expected an error for a malicious relationship type")
+	_, err = repository.ReadRelatedGraphEntityIds(ctx, "byname-1", malicious, "2024-01-01T00:00:00Z")
+	assert.NotNil(t, err, "Expected an error for a malicious relationship type")
+	_, err = repository.ReadGraphEntity(ctx, "byname-2")
+	assert.Nil(t, err, "Expected the related entity to be kept")
+}
+
+// TestGetGraphRelationshipsDirection tests that GetGraphRelationships returns both outgoing and incoming relationships of the same type
+func TestGetGraphRelationshipsDirection(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Create three entities
+	for _, entity := range []map[string]interface{}{
+		{"Id": "dir-a", "Name": "Alice", "Created": "2023-01-01T00:00:00Z"},
+		{"Id": "dir-b", "Name": "Bob", "Created": "2023-01-01T00:00:00Z"},
+		{"Id": "dir-c", "Name": "Carol", "Created": "2023-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+
+	// A knows B, and C knows A
+	_, err := repository.CreateRelationship(ctx, "dir-a", &pb.Relationship{
+		Id:              "dir-rel-out",
+		Name:            "KNOWS",
+		RelatedEntityId: "dir-b",
+		StartTime:       "2023-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the outgoing relationship")
+
+	_, err = repository.CreateRelationship(ctx, "dir-c", &pb.Relationship{
+		Id:              "dir-rel-in",
+		Name:            "KNOWS",
+		RelatedEntityId: "dir-a",
+		StartTime:       "2023-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the incoming relationship")
+
+	// Both KNOWS relationships are returned with their direction and the entity at the other end
+	relationships, err := repository.GetGraphRelationships(ctx, "dir-a")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	assert.Equal(t, 2, len(relationships), "Expected both KNOWS relationships")
+
+	byID := make(map[string]*pb.RelationshipWithDirection)
+	for _, rel := range relationships {
+		assert.Equal(t, "KNOWS", rel.Relationship.Name)
+		byID[rel.Relationship.Id] = rel
+	}
+	if assert.Contains(t, byID, "dir-rel-out") {
+		assert.Equal(t, pb.Direction_OUTGOING, byID["dir-rel-out"].Direction)
+		assert.Equal(t, "dir-b", byID["dir-rel-out"].RelatedEntityId)
+	}
+	if assert.Contains(t, byID, "dir-rel-in") {
+		assert.Equal(t, pb.Direction_INCOMING, byID["dir-rel-in"].Direction)
+		assert.Equal(t, "dir-c", byID["dir-rel-in"].RelatedEntityId)
+	}
+
+	// Only the outgoing relationship is stored on the entity
+	outgoing := OutgoingRelationships(relationships)
+	assert.Equal(t, 1, len(outgoing))
+	assert.Contains(t, outgoing, "dir-rel-out")
+}
+
+// TestReadRelationshipsAt tests the ReadRelationshipsAt method of the Neo4jRepository
+func TestReadRelationshipsAt(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Create two entities
+	for _, entity := range []map[string]interface{}{
+		{"Id": "at-1", "Name": "Ivan", "Created": "2023-01-01T00:00:00Z"},
+		{"Id": "at-2", "Name": "Judy", "Created": "2023-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+
+	// Create a relationship terminated in 2025
+	_, err := repository.CreateRelationship(ctx, "at-1", &pb.Relationship{
+		Id:              "at-rel-1",
+		Name:            "KNOWS",
+		RelatedEntityId: "at-2",
+		StartTime:       "2023-01-01T00:00:00Z",
+		EndTime:         "2025-06-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the relationship")
+
+	// The relationship is valid in 2024 on both legs
+	relationships, err := repository.ReadRelationshipsAt(ctx, "at-1", "2024-01-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	assert.Equal(t, 1, len(relationships), "Expected the outgoing relationship in 2024")
+	assert.Equal(t, "OUTGOING", relationships[0]["direction"])
+
+	relationships, err = repository.ReadRelationshipsAt(ctx, "at-2", "2024-01-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	assert.Equal(t, 1, len(relationships), "Expected the incoming relationship in 2024")
+	assert.Equal(t, "INCOMING", relationships[0]["direction"])
+
+	// The relationship is excluded in 2026 on both legs
+	relationships, err = repository.ReadRelationshipsAt(ctx, "at-1", "2026-01-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	assert.Equal(t, 0, len(relationships), "Expected no outgoing relationship in 2026")
+
+	relationships, err = repository.ReadRelationshipsAt(ctx, "at-2", "2026-01-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	assert.Equal(t, 0, len(relationships), "Expected no incoming relationship in 2026")
+
+	// The unfiltered method still returns the relationship
+	relationships, err = repository.ReadRelationships(ctx, "at-1")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	assert.Equal(t, 1, len(relationships), "Expected the relationship without a timestamp")
+}
+
+// TestRelationshipProperties tests creating and reading a relationship carrying typed properties
+func TestRelationshipProperties(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Create two entities
+	for _, entity := range []map[string]interface{}{
+		{"Id": "props-1", "Name": "Karl", "Created": "2025-03-18T00:00:00Z"},
+		{"Id": "props-2", "Name": "Liam", "Created": "2025-03-18T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+
+	// Prepare a numeric and a string property
+	weight, err := anypb.New(wrapperspb.Int64(42))
+	assert.Nil(t, err)
+	role, err := anypb.New(wrapperspb.String("advisor"))
+	assert.Nil(t, err)
+
+	createdRelationship, err := repository.CreateRelationship(ctx, "props-1", &pb.Relationship{
+		Id:              "props-rel-1",
+		Name:            "ADVISES",
+		RelatedEntityId: "props-2",
+		StartTime:       "2025-03-18T00:00:00Z",
+		Properties: map[string]*anypb.Any{
+			"weight": weight,
+			"role":   role,
+		},
+	})
+	assert.Nil(t, err, "Expected no error when creating the relationship")
+	assert.Contains(t, createdRelationship, "properties", "Expected the created relationship to carry its properties")
+
+	// Read the relationship back and unpack the typed values
+	relationshipMap, err := repository.ReadRelationship(ctx, "props-rel-1")
+	assert.Nil(t, err, "Expected no error when fetching the relationship")
+	properties, ok := relationshipMap["properties"].(map[string]*anypb.Any)
+	assert.True(t, ok, "Expected typed properties on the relationship")
+
+	weightValue := &wrapperspb.Int64Value{}
+	assert.Nil(t, properties["weight"].UnmarshalTo(weightValue))
+	assert.Equal(t, int64(42), weightValue.Value)
+
+	roleValue := &wrapperspb.StringValue{}
+	assert.Nil(t, properties["role"].UnmarshalTo(roleValue))
+	assert.Equal(t, "advisor", roleValue.Value)
+
+	// The properties are also available on the relationships of the entity
+	relationships, err := repository.GetGraphRelationships(ctx, "props-1")
+	assert.Nil(t, err, "Expected no error when fetching relationships")
+	outgoing := OutgoingRelationships(relationships)
+	assert.Contains(t, outgoing, "props-rel-1")
+	assert.Equal(t, 2, len(outgoing["props-rel-1"].Properties))
+}
+
+// TestNewNeo4jRepositoryWithPoolConfig tests creating a repository with custom connection pool settings
+func TestNewNeo4jRepositoryWithPoolConfig(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Neo4jConfig{
+		URI:                          os.Getenv("NEO4J_URI"),
+		Username:                     os.Getenv("NEO4J_USER"),
+		Password:                     os.Getenv("NEO4J_PASSWORD"),
+		MaxConnectionPoolSize:        10,
+		MaxConnectionLifetime:        30 * time.Minute,
+		ConnectionAcquisitionTimeout: 10 * time.Second,
+		SocketConnectTimeout:         2 * time.Second,
+	}
+
+	// The driver accepts the configuration and connects
+	pooledRepository, err := NewNeo4jRepository(ctx, cfg, nil)
+	assert.Nil(t, err, "Expected no error when creating a repository with a custom pool size")
+	if pooledRepository != nil {
+		defer pooledRepository.Close(ctx)
+	}
+
+	// The configured values are applied to the driver config
+	driverConfig := &neo4j.Config{}
+	poolConfig(cfg)(driverConfig)
+	assert.Equal(t, 10, driverConfig.MaxConnectionPoolSize)
+	assert.Equal(t, 30*time.Minute, driverConfig.MaxConnectionLifetime)
+	assert.Equal(t, 10*time.Second, driverConfig.ConnectionAcquisitionTimeout)
+	assert.Equal(t, 2*time.Second, driverConfig.SocketConnectTimeout)
+
+	// Zero values fall back to the defaults
+	driverConfig = &neo4j.Config{}
+	poolConfig(&config.Neo4jConfig{})(driverConfig)
+	assert.Equal(t, DefaultMaxConnectionPoolSize, driverConfig.MaxConnectionPoolSize)
+	assert.Equal(t, DefaultMaxConnectionLifetime, driverConfig.MaxConnectionLifetime)
+	assert.Equal(t, DefaultConnectionAcquisitionTimeout, driverConfig.ConnectionAcquisitionTimeout)
+	assert.Equal(t, DefaultSocketConnectTimeout, driverConfig.SocketConnectTimeout)
+}
+
+// TestQueryMetrics tests that queries run by the repository are recorded by the metrics recorder
+func TestQueryMetrics(t *testing.T) {
+	ctx := context.Background()
+	metrics := NewQueryMetrics()
+	repository.SetMetricsRecorder(metrics)
+	defer repository.SetMetricsRecorder(nil)
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+	entity := map[string]interface{}{
+		"Id":      "metrics-1",
+		"Name":    "Metrics Person",
+		"Created": "2025-03-18T00:00:00Z",
+	}
+
+	_, err := repository.CreateGraphEntity(ctx, kind, entity)
+	assert.Nil(t, err, "Expected no error when creating an entity")
+
+	// CreateGraphEntity runs an existence check through EntityExists and a create query
+	assert.Equal(t, 1, metrics.Snapshot()["EntityExists"].Count, "Expected the existence check to be counted")
+	stats := metrics.Snapshot()["CreateGraphEntity"]
+	assert.Equal(t, 1, stats.Count, "Expected the query counter to count the create query")
+	assert.Equal(t, 0, stats.Errors, "Expected no query errors to be recorded")
+	assert.Greater(t, stats.TotalLatency, time.Duration(0), "Expected the query latency to be recorded")
+	assert.GreaterOrEqual(t, stats.TotalLatency, stats.MaxLatency, "Expected the total latency to include the slowest query")
+}
+
+// TestUpsertGraphEntity tests that UpsertGraphEntity creates a missing entity and updates it on the next call
+func TestUpsertGraphEntity(t *testing.T) {
+	ctx := context.Background()
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// The first call creates the entity
+	entity := map[string]interface{}{
+		"Id":      "upsert-1",
+		"Name":    "Upsert Person",
+		"Created": "2025-03-18T00:00:00Z",
+	}
+	created, err := repository.UpsertGraphEntity(ctx, kind, entity)
+	assert.Nil(t, err, "Expected no error when upserting a new entity")
+	assert.Equal(t, "upsert-1", created["Id"], "Expected the entity Id to match")
+	assert.Equal(t, "Upsert Person", created["Name"], "Expected the entity name to match")
+	assert.Equal(t, "2025-03-18T00:00:00Z", created["Created"], "Expected the created date to match")
+
+	// The second call updates Name and Terminated but keeps the original Created timestamp
+	entity = map[string]interface{}{
+		"Id":         "upsert-1",
+		"Name":       "Upsert Person Renamed",
+		"Created":    "2026-01-01T00:00:00Z",
+		"Terminated": "2026-12-31T00:00:00Z",
+	}
+	updated, err := repository.UpsertGraphEntity(ctx, kind, entity)
+	assert.Nil(t, err, "Expected no error when upserting an existing entity")
+	assert.Equal(t, "Upsert Person Renamed", updated["Name"], "Expected the entity name to be updated")
+	assert.Equal(t, "2025-03-18T00:00:00Z", updated["Created"], "Expected the created date to be preserved")
+	assert.Equal(t, "2026-12-31T00:00:00Z", updated["Terminated"], "Expected the terminated date to be set")
+
+	// Only one node exists for the Id
+	read, err := repository.ReadGraphEntity(ctx, "upsert-1")
+	assert.Nil(t, err, "Expected no error when reading the upserted entity")
+	assert.Equal(t, "Upsert Person Renamed", read["Name"], "Expected the stored name to be updated")
+}
+
+// TestUpsertGraphEntityIdConflict tests that UpsertGraphEntity does not create a second node for an Id used by another kind or tenant
+func TestUpsertGraphEntityIdConflict(t *testing.T) {
+	ctx := context.Background()
+	entity := map[string]interface{}{
+		"Id":      "upsert-conflict-1",
+		"Name":    "Conflict Person",
+		"Created": "2025-03-18T00:00:00Z",
+	}
+	_, err := repository.CreateGraphEntity(ctx, &pb.Kind{Major: "Person", Minor: "Minister"}, entity)
+	assert.Nil(t, err, "Expected no error when creating the entity")
+
+	// Another kind with the same Id is rejected
+	_, err = repository.UpsertGraphEntity(ctx, &pb.Kind{Major: "Organisation", Minor: "Department"}, entity)
+	assert.NotNil(t, err, "Expected an error when upserting the Id under another kind")
+
+	// Another tenant with the same Id is rejected
+	ctxA := WithTenant(ctx, "tenantA")
+	_, err = repository.UpsertGraphEntity(ctxA, &pb.Kind{Major: "Person", Minor: "Minister", Tenant: "tenantA"}, entity)
+	assert.NotNil(t, err, "Expected an error when upserting the Id for another tenant")
+
+	// The original entity is left unchanged
+	read, err := repository.ReadGraphEntity(ctx, "upsert-conflict-1")
+	assert.Nil(t, err, "Expected no error when reading the entity")
+	assert.Equal(t, "Person", read["MajorKind"], "Expected the original kind to be kept")
+}
+
+// TestTraverseGraph tests following relationship chains with TraverseGraph
+func TestTraverseGraph(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Create a chain of three entities: traverse-1 -> traverse-2 -> traverse-3
+	for _, entity := range []map[string]interface{}{
+		{"Id": "traverse-1", "Name": "Mia", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "traverse-2", "Name": "Noah", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "traverse-3", "Name": "Olga", "Created": "2025-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+	for _, rel := range []struct{ from, id, to string }{
+		{"traverse-1", "traverse-rel-1", "traverse-2"},
+		{"traverse-2", "traverse-rel-2", "traverse-3"},
+	} {
+		_, err := repository.CreateRelationship(ctx, rel.from, &pb.Relationship{
+			Id:              rel.id,
+			Name:            "REPORTS_TO",
+			RelatedEntityId: rel.to,
+			StartTime:       "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating relationship %s", rel.id)
+	}
+	ts := "2025-06-01T00:00:00Z"
+
+	// Depth 1 returns the same entities as ReadRelatedGraphEntityIds
+	entities, err := repository.TraverseGraph(ctx, "traverse-1", "REPORTS_TO", 1, ts)
+	assert.Nil(t, err, "Expected no error when traversing to depth 1")
+	related, err := repository.ReadRelatedGraphEntityIds(ctx, "traverse-1", "REPORTS_TO", ts)
+	assert.Nil(t, err, "Expected no error when reading related entities")
+	assert.Equal(t, len(related), len(entities), "Expected depth 1 to match the direct neighbours")
+	assert.Equal(t, related[0]["RelatedEntityId"], entities[0]["Id"], "Expected depth 1 to return the direct neighbour")
+	assert.Equal(t, 1, entities[0]["Depth"], "Expected the direct neighbour at depth 1")
+
+	// Depth 2 reaches the end of the chain
+	entities, err = repository.TraverseGraph(ctx, "traverse-1", "REPORTS_TO", 2, ts)
+	assert.Nil(t, err, "Expected no error when traversing to depth 2")
+	assert.Equal(t, 2, len(entities), "Expected both entities in the chain")
+	assert.Equal(t, "traverse-2", entities[0]["Id"])
+	assert.Equal(t, 1, entities[0]["Depth"])
+	assert.Equal(t, "traverse-3", entities[1]["Id"])
+	assert.Equal(t, 2, entities[1]["Depth"])
+
+	// Close the chain into a cycle: each entity is still returned once and the start is excluded
+	_, err = repository.CreateRelationship(ctx, "traverse-3", &pb.Relationship{
+		Id:              "traverse-rel-3",
+		Name:            "REPORTS_TO",
+		RelatedEntityId: "traverse-1",
+		StartTime:       "2025-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the cycle")
+
+	entities, err = repository.TraverseGraph(ctx, "traverse-1", "REPORTS_TO", 5, ts)
+	assert.Nil(t, err, "Expected no error when traversing a cycle")
+	assert.Equal(t, 2, len(entities), "Expected no duplicate entities in a cycle")
+	assert.Equal(t, 1, entities[0]["Depth"], "Expected the shortest depth for each entity")
+	assert.Equal(t, 2, entities[1]["Depth"], "Expected the shortest depth for each entity")
+
+	// Invalid depths are rejected
+	_, err = repository.TraverseGraph(ctx, "traverse-1", "REPORTS_TO", 0, ts)
+	assert.NotNil(t, err, "Expected an error for a depth of 0")
+	_, err = repository.TraverseGraph(ctx, "traverse-1", "REPORTS_TO", MaxTraversalDepth+1, ts)
+	assert.NotNil(t, err, "Expected an error for a depth above the maximum")
+
+	// Unsafe relationship types are rejected
+	_, err = repository.TraverseGraph(ctx, "traverse-1", "REPORTS_TO]->() DETACH DELETE (n", 1, ts)
+	assert.NotNil(t, err, "Expected an error for an unsafe relationship type")
+}
+
+// TestValidateTimeInterval tests that inverted relationship intervals are rejected
+func TestValidateTimeInterval(t *testing.T) {
+	ctx := context.Background()
+
+	// A valid interval, a plain date interval and an open-ended interval are accepted
+	assert.Nil(t, ValidateTimeInterval("2024-01-01T00:00:00Z", "2025-01-01T00:00:00Z"), "Expected a valid interval to pass")
+	assert.Nil(t, ValidateTimeInterval("2024-01-01", "2025-01-01"), "Expected a plain date interval to pass")
+	assert.Nil(t, ValidateTimeInterval("2024-01-01T00:00:00Z", ""), "Expected an open-ended interval to pass")
+
+	// An inverted interval and an unparseable end time are rejected
+	assert.NotNil(t, ValidateTimeInterval("2025-01-01T00:00:00Z", "2024-01-01T00:00:00Z"), "Expected an inverted interval to fail")
+	assert.NotNil(t, ValidateTimeInterval("2024-01-01T00:00:00Z", "not-a-date"), "Expected an invalid end time to fail")
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+	for _, entity := range []map[string]interface{}{
+		{"Id": "interval-1", "Name": "Pia", "Created": "2024-01-01T00:00:00Z"},
+		{"Id": "interval-2", "Name": "Quinn", "Created": "2024-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+
+	// CreateRelationship rejects an inverted interval
+	_, err := repository.CreateRelationship(ctx, "interval-1", &pb.Relationship{
+		Id:              "interval-rel-1",
+		Name:            "KNOWS",
+		RelatedEntityId: "interval-2",
+		StartTime:       "2025-01-01T00:00:00Z",
+		EndTime:         "2024-01-01T00:00:00Z",
+	})
+	assert.NotNil(t, err, "Expected an error when creating a relationship with an inverted interval")
+
+	// HandleGraphRelationshipsCreate rejects an inverted interval
+	err = repository.HandleGraphRelationshipsCreate(ctx, &pb.Entity{
+		Id: "interval-1",
+		Relationships: map[string]*pb.Relationship{
+			"interval-rel-2": {
+				Id:              "interval-rel-2",
+				Name:            "KNOWS",
+				RelatedEntityId: "interval-2",
+				StartTime:       "2025-01-01T00:00:00Z",
+				EndTime:         "2024-01-01T00:00:00Z",
+			},
+		},
+	})
+	assert.NotNil(t, err, "Expected an error when handling a relationship with an inverted interval")
+
+	// An open-ended relationship is created
+	_, err = repository.CreateRelationship(ctx, "interval-1", &pb.Relationship{
+		Id:              "interval-rel-3",
+		Name:            "KNOWS",
+		RelatedEntityId: "interval-2",
+		StartTime:       "2024-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating an open-ended relationship")
+}
+
+// TestFilterEntitiesNameContains tests the full-text name_contains filter of FilterEntities
+func TestFilterEntitiesNameContains(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "SearchPerson",
+		Minor: "Minister",
+	}
+
+	// Create several entities with similar names
+	for _, entity := range []map[string]interface{}{
+		{"Id": "search-1", "Name": "Rohan Perera", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "search-2", "Name": "Roshan Perera", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "search-3", "Name": "Rohan Silva", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "search-4", "Name": "Nimal Fernando", "Created": "2025-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+
+	// EnsureIndexes can be called repeatedly
+	assert.Nil(t, repository.EnsureIndexes(ctx), "Expected no error when ensuring indexes")
+	assert.Nil(t, repository.EnsureIndexes(ctx), "Expected no error when ensuring indexes again")
+
+	// A partial first name matches only the entities with that name
+	entities, err := repository.FilterEntities(ctx, kind, map[string]interface{}{"name_contains": "roha"})
+	assert.Nil(t, err, "Expected no error when searching by partial name")
+	assert.Equal(t, 2, len(entities), "Expected two entities matching the partial name")
+	assert.Equal(t, "search-1", entities[0]["id"])
+	assert.Equal(t, "search-3", entities[1]["id"])
+
+	// Several words must all match
+	entities, err = repository.FilterEntities(ctx, kind, map[string]interface{}{"name_contains": "Rohan Per"})
+	assert.Nil(t, err, "Expected no error when searching by several words")
+	assert.Equal(t, 1, len(entities), "Expected one entity matching every word")
+	assert.Equal(t, "search-1", entities[0]["id"])
+
+	// The search combines with the exact filters
+	entities, err = repository.FilterEntities(ctx, kind, map[string]interface{}{"name_contains": "perera", "id": "search-2"})
+	assert.Nil(t, err, "Expected no error when combining filters")
+	assert.Equal(t, 1, len(entities), "Expected the search to be narrowed by the id filter")
+	assert.Equal(t, "search-2", entities[0]["id"])
+}
+
+// TestReadRelationshipsOfTypes tests reading only the relationships of selected types and direction
+func TestReadRelationshipsOfTypes(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Organisation",
+		Minor: "Ministry",
+	}
+
+	// Create a ministry related to three entities by three different relationship types
+	for _, entity := range []map[string]interface{}{
+		{"Id": "types-1", "Name": "Ministry of Types", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "types-2", "Name": "Department of Types", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "types-3", "Name": "Minister of Types", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "types-4", "Name": "Office of Types", "Created": "2025-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+	for _, rel := range []struct{ id, name, to string }{
+		{"types-rel-1", "is_department", "types-2"},
+		{"types-rel-2", "reports_to", "types-3"},
+		{"types-rel-3", "located_in", "types-4"},
+	} {
+		_, err := repository.CreateRelationship(ctx, "types-1", &pb.Relationship{
+			Id:              rel.id,
+			Name:            rel.name,
+			RelatedEntityId: rel.to,
+			StartTime:       "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating relationship %s", rel.id)
+	}
+
+	// Requesting two of the three types returns only those
+	relationships, err := repository.ReadRelationshipsOfTypes(ctx, "types-1", []string{"is_department", "reports_to"}, DirectionOutgoing)
+	assert.Nil(t, err, "Expected no error when reading relationships of types")
+	assert.Equal(t, 2, len(relationships), "Expected only the two requested relationship types")
+	for _, rel := range relationships {
+		assert.Contains(t, []string{"is_department", "reports_to"}, rel["type"], "Expected only requested types")
+		assert.Equal(t, "OUTGOING", rel["direction"], "Expected only outgoing relationships")
+	}
+
+	// The direction limits the legs that are read
+	relationships, err = repository.ReadRelationshipsOfTypes(ctx, "types-1", []string{"is_department", "reports_to"}, DirectionIncoming)
+	assert.Nil(t, err, "Expected no error when reading incoming relationships")
+	assert.Equal(t, 0, len(relationships), "Expected no incoming relationships for the ministry")
+
+	relationships, err = repository.ReadRelationshipsOfTypes(ctx, "types-2", []string{"is_department"}, DirectionBoth)
+	assert.Nil(t, err, "Expected no error when reading relationships in both directions")
+	assert.Equal(t, 1, len(relationships), "Expected the incoming is_department relationship")
+	assert.Equal(t, "INCOMING", relationships[0]["direction"])
+
+	// An invalid direction is rejected
+	_, err = repository.ReadRelationshipsOfTypes(ctx, "types-1", nil, Direction("SIDEWAYS"))
+	assert.NotNil(t, err, "Expected an error for an invalid direction")
+}
+
+// TestExportToJSONLD tests exporting a small subgraph as a JSON-LD document
+func TestExportToJSONLD(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Create a chain export-1 -> export-2 -> export-3
+	for _, entity := range []map[string]interface{}{
+		{"Id": "export-1", "Name": "Ravi", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "export-2", "Name": "Sara", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "export-3", "Name": "Tara", "Created": "2025-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+	for _, rel := range []struct{ from, id, to string }{
+		{"export-1", "export-rel-1", "export-2"},
+		{"export-2", "export-rel-2", "export-3"},
+	} {
+		_, err := repository.CreateRelationship(ctx, rel.from, &pb.Relationship{
+			Id:              rel.id,
+			Name:            "ADVISES",
+			RelatedEntityId: rel.to,
+			StartTime:       "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating relationship %s", rel.id)
+	}
+
+	repository.ContextMap = map[string]string{"Person": "http://schema.org/Person"}
+	defer func() { repository.ContextMap = nil }()
+
+	// Export one hop from the root
+	data, err := repository.ExportToJSONLD(ctx, "export-1", 1)
+	assert.Nil(t, err, "Expected no error when exporting to JSON-LD")
+
+	var document map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &document), "Expected the export to be valid JSON")
+
+	jsonLDContext := document["@context"].(map[string]interface{})
+	assert.Equal(t, "http://schema.org/Person", jsonLDContext["Person"], "Expected the kind to be mapped in @context")
+	assert.Equal(t, DefaultJSONLDVocab, jsonLDContext["@vocab"], "Expected the default vocabulary in @context")
+
+	graph := document["@graph"].([]interface{})
+	assert.Equal(t, 2, len(graph), "Expected the root and its direct neighbour only")
+
+	nodes := make(map[string]map[string]interface{})
+	for _, item := range graph {
+		node := item.(map[string]interface{})
+		nodes[node["@id"].(string)] = node
+	}
+	assert.Contains(t, nodes, "export-1", "Expected the root node")
+	assert.Contains(t, nodes, "export-2", "Expected the direct neighbour")
+	assert.NotContains(t, nodes, "export-3", "Expected the export to be bounded by the depth")
+	assert.Equal(t, "Person", nodes["export-1"]["@type"], "Expected the node type to be the kind")
+	assert.Equal(t, "Ravi", nodes["export-1"]["name"], "Expected the node name")
+
+	// The relationship to an exported node is a predicate, the one leaving the export is dropped
+	predicate := nodes["export-1"]["ADVISES"].([]interface{})
+	assert.Equal(t, "export-2", predicate[0].(map[string]interface{})["@id"], "Expected the relationship predicate")
+	assert.NotContains(t, nodes["export-2"], "ADVISES", "Expected relationships outside the export to be omitted")
+}
+
+// TestQueryTimeout tests that a query blocked longer than the configured timeout fails with ErrQueryTimeout
+func TestQueryTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+	_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+		"Id":      "timeout-1",
+		"Name":    "Uma",
+		"Created": "2025-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the entity")
+
+	// Hold a write lock on the entity in an open transaction so that other writes block
+	lockSession := repository.getSession(ctx)
+	defer lockSession.Close(ctx)
+	tx, err := lockSession.BeginTransaction(ctx)
+	assert.Nil(t, err, "Expected no error when beginning the locking transaction")
+	defer tx.Rollback(ctx)
+	_, err = tx.Run(ctx, `MATCH (e {Id: $Id}) SET e.Name = 'Locked'`, map[string]interface{}{"Id": "timeout-1"})
+	assert.Nil(t, err, "Expected no error when locking the entity")
+
+	// A repository with a short query timeout
+	cfg := &config.Neo4jConfig{
+		URI:          os.Getenv("NEO4J_URI"),
+		Username:     os.Getenv("NEO4J_USER"),
+		Password:     os.Getenv("NEO4J_PASSWORD"),
+		QueryTimeout: 500 * time.Millisecond,
+	}
+	timeoutRepository, err := NewNeo4jRepository(ctx, cfg, nil)
+	assert.Nil(t, err, "Expected no error when creating the repository")
+	defer timeoutRepository.Close(ctx)
+
+	// The blocked write returns a timeout error instead of hanging
+	session := timeoutRepository.getSession(ctx)
+	defer session.Close(ctx)
+	start := time.Now()
+	_, err = timeoutRepository.run(ctx, session, "TestQueryTimeout", `MATCH (e {Id: $Id}) SET e.Name = 'Blocked' RETURN e`, map[string]interface{}{"Id": "timeout-1"})
+	assert.ErrorIs(t, err, ErrQueryTimeout, "Expected the blocked query to time out")
+	assert.Less(t, time.Since(start), 10*time.Second, "Expected the query to stop at the timeout")
+
+	// The repository methods surface the timeout in their error
+	_, err = timeoutRepository.UpdateGraphEntity(ctx, "timeout-1", map[string]interface{}{"Name": "Blocked"})
+	assert.NotNil(t, err, "Expected an error when updating a locked entity")
+	if err != nil {
+		assert.Contains(t, err.Error(), ErrQueryTimeout.Error(), "Expected the error to report the timeout")
+	}
+}
+
+// TestReadGraphEntitiesByIds tests reading several entities in one call
+func TestReadGraphEntitiesByIds(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Create three entities, one of them terminated
+	for _, entity := range []map[string]interface{}{
+		{"Id": "bulk-read-1", "Name": "Vera", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "bulk-read-2", "Name": "Will", "Created": "2025-02-01T00:00:00Z"},
+		{"Id": "bulk-read-3", "Name": "Xena", "Created": "2025-03-01T00:00:00Z", "Terminated": "2025-12-31T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+
+	// Read all three plus a missing Id
+	entities, err := repository.ReadGraphEntitiesByIds(ctx, []string{"bulk-read-1", "bulk-read-2", "bulk-read-3", "bulk-read-missing"})
+	assert.Nil(t, err, "Expected no error when reading entities by Ids")
+	assert.Equal(t, 3, len(entities), "Expected the missing Id to be omitted")
+	assert.NotContains(t, entities, "bulk-read-missing", "Expected the missing Id to be omitted")
+
+	// The entities are formatted as in ReadGraphEntity
+	for _, id := range []string{"bulk-read-1", "bulk-read-2", "bulk-read-3"} {
+		single, err := repository.ReadGraphEntity(ctx, id)
+		assert.Nil(t, err, "Expected no error when reading entity %s", id)
+		assert.Equal(t, single, entities[id], "Expected the bulk read to match ReadGraphEntity for %s", id)
+	}
+	assert.Equal(t, "Person", entities["bulk-read-1"]["MajorKind"], "Expected MajorKind from the label")
+	assert.Equal(t, "2025-12-31T00:00:00Z", entities["bulk-read-3"]["Terminated"], "Expected the terminated date")
+
+	// An empty list returns no entities
+	entities, err = repository.ReadGraphEntitiesByIds(ctx, nil)
+	assert.Nil(t, err, "Expected no error when reading no entities")
+	assert.Equal(t, 0, len(entities), "Expected no entities")
+}
+
+// TestDeleteEntityReassigning tests deleting a ministry after moving its departments to another ministry
+func TestDeleteEntityReassigning(t *testing.T) {
+	ctx := context.Background()
+
+	ministryKind := &pb.Kind{Major: "Organisation", Minor: "Ministry"}
+	departmentKind := &pb.Kind{Major: "Organisation", Minor: "Department"}
+
+	for _, entity := range []map[string]interface{}{
+		{"Id": "reassign-old", "Name": "Old Ministry", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "reassign-new", "Name": "New Ministry", "Created": "2025-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, ministryKind, entity)
+		assert.Nil(t, err, "Expected no error when creating ministry %s", entity["Id"])
+	}
+	for i, id := range []string{"reassign-dept-1", "reassign-dept-2"} {
+		_, err := repository.CreateGraphEntity(ctx, departmentKind, map[string]interface{}{
+			"Id": id, "Name": fmt.Sprintf("Department %d", i+1), "Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating department %s", id)
+
+		_, err = repository.CreateRelationship(ctx, "reassign-old", &pb.Relationship{
+			Id:              "reassign-rel-" + id,
+			Name:            "is_department",
+			RelatedEntityId: id,
+			StartTime:       "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when linking department %s", id)
+	}
+
+	// Reassign the departments and delete the old ministry
+	err := repository.DeleteEntityReassigning(ctx, "reassign-old", "reassign-new", "is_department")
+	assert.Nil(t, err, "Expected no error when deleting with reassignment")
+
+	_, err = repository.ReadGraphEntity(ctx, "reassign-old")
+	assert.NotNil(t, err, "Expected the old ministry to be deleted")
+
+	// The departments are intact and now belong to the new ministry
+	relationships, err := repository.ReadRelationshipsOfTypes(ctx, "reassign-new", []string{"is_department"}, DirectionOutgoing)
+	assert.Nil(t, err, "Expected no error when reading the new ministry relationships")
+	assert.Equal(t, 2, len(relationships), "Expected both departments to be reassigned")
+	for _, rel := range relationships {
+		assert.Contains(t, []string{"reassign-dept-1", "reassign-dept-2"}, rel["relatedID"], "Expected a reassigned department")
+		assert.Equal(t, "reassign-rel-"+rel["relatedID"].(string), rel["relationshipID"], "Expected the relationship Id to be kept")
+		assert.Equal(t, "2025-01-01T00:00:00Z", rel["Created"], "Expected the start date to be kept")
+	}
+	for _, id := range []string{"reassign-dept-1", "reassign-dept-2"} {
+		_, err := repository.ReadGraphEntity(ctx, id)
+		assert.Nil(t, err, "Expected department %s to still exist", id)
+	}
+
+	// A missing new parent leaves the entity in place
+	err = repository.DeleteEntityReassigning(ctx, "reassign-new", "reassign-missing", "is_department")
+	assert.NotNil(t, err, "Expected an error when the new parent does not exist")
+	_, err = repository.ReadGraphEntity(ctx, "reassign-new")
+	assert.Nil(t, err, "Expected the entity to remain after a failed reassignment")
+
+	// An unsafe relationship type is rejected before any query runs
+	err = repository.DeleteEntityReassigning(ctx, "reassign-new", "reassign-old", "is_department]->() DETACH DELETE (n")
+	assert.NotNil(t, err, "Expected an unsafe relationship type to be rejected")
+	_, err = repository.ReadGraphEntity(ctx, "reassign-new")
+	assert.Nil(t, err, "Expected the entity to remain after a rejected relationship type")
+}
+
+// TestFilterEntitiesPaging tests paging through FilterEntities results with skip and limit
+func TestFilterEntitiesPaging(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "PagingPerson",
+		Minor: "Minister",
+	}
+	for i := 1; i <= 5; i++ {
+		_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+			"Id":      fmt.Sprintf("paging-%d", i),
+			"Name":    fmt.Sprintf("Paging Person %d", i),
+			"Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating entity paging-%d", i)
+	}
+
+	// Pages follow the Id order and the last page is short
+	var ids []string
+	for skip := 0; skip < 6; skip += 2 {
+		entities, err := repository.FilterEntities(ctx, kind, map[string]interface{}{"skip": skip, "limit": 2})
+		assert.Nil(t, err, "Expected no error when fetching a page")
+		assert.LessOrEqual(t, len(entities), 2, "Expected at most one page of entities")
+		for _, entity := range entities {
+			ids = append(ids, entity["id"].(string))
+		}
+	}
+	assert.Equal(t, []string{"paging-1", "paging-2", "paging-3", "paging-4", "paging-5"}, ids, "Expected every entity exactly once in order")
+}
+
+// TestFindEntitiesCreatedBetween tests finding entities of different kinds created within a time window
+func TestFindEntitiesCreatedBetween(t *testing.T) {
+	ctx := context.Background()
+
+	// Entities of different kinds, two inside the window and two outside
+	for _, entity := range []struct {
+		kind *pb.Kind
+		data map[string]interface{}
+	}{
+		{&pb.Kind{Major: "Person", Minor: "Minister"}, map[string]interface{}{"Id": "window-1", "Name": "Yara", "Created": "2019-06-01T08:00:00Z"}},
+		{&pb.Kind{Major: "Organisation", Minor: "Department"}, map[string]interface{}{"Id": "window-2", "Name": "Window Department", "Created": "2019-06-01T17:30:00Z"}},
+		{&pb.Kind{Major: "Person", Minor: "Minister"}, map[string]interface{}{"Id": "window-3", "Name": "Zane", "Created": "2019-05-31T23:59:59Z"}},
+		{&pb.Kind{Major: "Organisation", Minor: "Ministry"}, map[string]interface{}{"Id": "window-4", "Name": "Window Ministry", "Created": "2019-06-02T00:00:00Z"}},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, entity.kind, entity.data)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity.data["Id"])
+	}
+
+	// Only the entities inside the window are returned, across kinds and oldest first
+	entities, err := repository.FindEntitiesCreatedBetween(ctx, "2019-06-01T00:00:00Z", "2019-06-02T00:00:00Z", 0)
+	assert.Nil(t, err, "Expected no error when finding entities in the window")
+	assert.Equal(t, 2, len(entities), "Expected the two entities created in the window")
+	assert.Equal(t, "window-1", entities[0]["Id"])
+	assert.Equal(t, "Person", entities[0]["MajorKind"])
+	assert.Equal(t, "window-2", entities[1]["Id"])
+	assert.Equal(t, "Organisation", entities[1]["MajorKind"])
+
+	// The limit caps the results
+	entities, err = repository.FindEntitiesCreatedBetween(ctx, "2019-06-01T00:00:00Z", "2019-06-02T00:00:00Z", 1)
+	assert.Nil(t, err, "Expected no error when finding entities with a limit")
+	assert.Equal(t, 1, len(entities), "Expected the limit to cap the results")
+
+	// An inverted window is rejected
+	_, err = repository.FindEntitiesCreatedBetween(ctx, "2019-06-02T00:00:00Z", "2019-06-01T00:00:00Z", 0)
+	assert.NotNil(t, err, "Expected an error for an inverted window")
+}
+
+// TestEntityExists tests the existence check for existing and missing entities
+func TestEntityExists(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := repository.CreateGraphEntity(ctx, &pb.Kind{Major: "Person", Minor: "Minister"}, map[string]interface{}{
+		"Id":      "exists-1",
+		"Name":    "Ada",
+		"Created": "2025-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the entity")
+
+	exists, err := repository.EntityExists(ctx, "exists-1")
+	assert.Nil(t, err, "Expected no error when checking an existing entity")
+	assert.True(t, exists, "Expected the created entity to exist")
+
+	exists, err = repository.EntityExists(ctx, "exists-missing")
+	assert.Nil(t, err, "Expected no error when checking a missing entity")
+	assert.False(t, exists, "Expected a missing entity not to exist")
+
+	// The same Id cannot be created again, even under another kind
+	_, err = repository.CreateGraphEntity(ctx, &pb.Kind{Major: "Organisation", Minor: "Ministry"}, map[string]interface{}{
+		"Id":      "exists-1",
+		"Name":    "Ada Ministry",
+		"Created": "2025-01-01T00:00:00Z",
+	})
+	assert.NotNil(t, err, "Expected an error when creating an entity with an existing Id")
+}
+
+// TestCopyRelationships tests copying an entity's relationships onto another entity
+func TestCopyRelationships(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{Major: "Organisation", Minor: "Ministry"}
+	for _, id := range []string{"copy-source", "copy-target", "copy-child", "copy-parent"} {
+		_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+			"Id": id, "Name": "Entity " + id, "Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating entity %s", id)
+	}
+	for _, link := range []struct{ parent, child, id string }{
+		{"copy-source", "copy-child", "copy-rel-out"},
+		{"copy-parent", "copy-source", "copy-rel-in"},
+		{"copy-source", "copy-target", "copy-rel-between"},
+	} {
+		_, err := repository.CreateRelationship(ctx, link.parent, &pb.Relationship{
+			Id:              link.id,
+			Name:            "is_related",
+			RelatedEntityId: link.child,
+			StartTime:       "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating relationship %s", link.id)
+	}
+
+	// The relationship between source and target is not copied
+	copied, err := repository.CopyRelationships(ctx, "copy-source", "copy-target")
+	assert.Nil(t, err, "Expected no error when copying relationships")
+	assert.Equal(t, 2, copied, "Expected the incoming and outgoing relationships to be copied")
+
+	relationships, err := repository.ReadRelationshipsOfTypes(ctx, "copy-target", []string{"is_related"}, DirectionBoth)
+	assert.Nil(t, err, "Expected no error when reading the target relationships")
+	byID := make(map[string]map[string]interface{})
+	for _, rel := range relationships {
+		byID[rel["relationshipID"].(string)] = rel
+	}
+	assert.Equal(t, "copy-child", byID["copy-rel-out_copy-target"]["relatedID"], "Expected the outgoing relationship on the target")
+	assert.Equal(t, "OUTGOING", byID["copy-rel-out_copy-target"]["direction"])
+	assert.Equal(t, "copy-parent", byID["copy-rel-in_copy-target"]["relatedID"], "Expected the incoming relationship on the target")
+	assert.Equal(t, "INCOMING", byID["copy-rel-in_copy-target"]["direction"])
+	assert.Equal(t, "2025-01-01T00:00:00Z", byID["copy-rel-out_copy-target"]["Created"], "Expected the start date to be kept")
+
+	// Copying again doesn't duplicate anything
+	_, err = repository.CopyRelationships(ctx, "copy-source", "copy-target")
+	assert.Nil(t, err, "Expected no error when copying relationships again")
+	relationships, err = repository.ReadRelationshipsOfTypes(ctx, "copy-target", []string{"is_related"}, DirectionBoth)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(relationships), "Expected the copies plus the relationship from the source")
+
+	// A missing target is rejected
+	_, err = repository.CopyRelationships(ctx, "copy-source", "copy-missing")
+	assert.NotNil(t, err, "Expected an error when the target does not exist")
+}
+
+// TestCreateRelationshipRules tests that CreateRelationship enforces the configured relationship rules
+func TestCreateRelationshipRules(t *testing.T) {
+	ctx := context.Background()
+
+	repository.RelationshipRules = []RelationshipRule{{
+		Type: "is_department",
+		From: &pb.Kind{Major: "Organization", Minor: "Ministry"},
+		To:   &pb.Kind{Major: "Organization", Minor: "Department"},
+	}}
+	defer func() { repository.RelationshipRules = nil }()
+
+	for _, entity := range []struct {
+		id   string
+		kind *pb.Kind
+	}{
+		{"rules-ministry", &pb.Kind{Major: "Organization", Minor: "Ministry"}},
+		{"rules-department", &pb.Kind{Major: "Organization", Minor: "Department"}},
+		{"rules-person", &pb.Kind{Major: "Person", Minor: "Citizen"}},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, entity.kind, map[string]interface{}{
+			"Id": entity.id, "Name": "Entity " + entity.id, "Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity.id)
+	}
+
+	// A ministry may have a department
+	_, err := repository.CreateRelationship(ctx, "rules-ministry", &pb.Relationship{
+		Id:              "rules-rel-allowed",
+		Name:            "is_department",
+		RelatedEntityId: "rules-department",
+		StartTime:       "2025-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected the ministry to department relationship to be allowed")
+
+	// A person may not
+	_, err = repository.CreateRelationship(ctx, "rules-person", &pb.Relationship{
+		Id:              "rules-rel-disallowed",
+		Name:            "is_department",
+		RelatedEntityId: "rules-ministry",
+		StartTime:       "2025-01-01T00:00:00Z",
+	})
+	assert.NotNil(t, err, "Expected the person to ministry relationship to be rejected")
+
+	_, err = repository.ReadRelationship(ctx, "rules-rel-disallowed")
+	assert.NotNil(t, err, "Expected the rejected relationship not to be created")
+}
+
+// TestTerminateGraphEntity tests that terminating an entity stamps the entity and its open outgoing relationships
+func TestTerminateGraphEntity(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{Major: "Organisation", Minor: "Ministry"}
+	for _, id := range []string{"terminate-ministry", "terminate-dept-open", "terminate-dept-closed"} {
+		_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+			"Id": id, "Name": "Entity " + id, "Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating entity %s", id)
+	}
+	_, err := repository.CreateRelationship(ctx, "terminate-ministry", &pb.Relationship{
+		Id:              "terminate-rel-open",
+		Name:            "is_department",
+		RelatedEntityId: "terminate-dept-open",
+		StartTime:       "2025-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the open relationship")
+	_, err = repository.CreateRelationship(ctx, "terminate-ministry", &pb.Relationship{
+		Id:              "terminate-rel-closed",
+		Name:            "is_department",
+		RelatedEntityId: "terminate-dept-closed",
+		StartTime:       "2025-01-01T00:00:00Z",
+		EndTime:         "2025-03-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the terminated relationship")
+
+	entity, err := repository.TerminateGraphEntity(ctx, "terminate-ministry", "2025-06-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when terminating the entity")
+	assert.Equal(t, "2025-06-01T00:00:00Z", entity["Terminated"], "Expected the entity to be terminated")
+
+	// The entity is still readable
+	readEntity, err := repository.ReadGraphEntity(ctx, "terminate-ministry")
+	assert.Nil(t, err, "Expected the terminated entity to still exist")
+	assert.Equal(t, "2025-06-01T00:00:00Z", readEntity["Terminated"])
+
+	// Only the open relationship gets the termination date
+	openRel, err := repository.ReadRelationship(ctx, "terminate-rel-open")
+	assert.Nil(t, err)
+	assert.Equal(t, "2025-06-01T00:00:00Z", openRel["Terminated"], "Expected the open relationship to be terminated")
+	closedRel, err := repository.ReadRelationship(ctx, "terminate-rel-closed")
+	assert.Nil(t, err)
+	assert.Equal(t, "2025-03-01T00:00:00Z", closedRel["Terminated"], "Expected the terminated relationship to keep its date")
+
+	_, err = repository.TerminateGraphEntity(ctx, "terminate-missing", "2025-06-01T00:00:00Z")
+	assert.NotNil(t, err, "Expected an error for a missing entity")
+	_, err = repository.TerminateGraphEntity(ctx, "terminate-ministry", "not-a-date")
+	assert.NotNil(t, err, "Expected an error for an invalid date")
+}
+
+// TestConnectionFailureLogging tests that a query against an unreachable Neo4j logs at ERROR level with the entity Id
+func TestConnectionFailureLogging(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Nothing listens on this port, so every query fails to connect
+	driver, err := neo4j.NewDriverWithContext("bolt://127.0.0.1:1", neo4j.NoAuth())
+	assert.Nil(t, err, "Expected the driver to be created without connecting")
+	defer driver.Close(ctx)
+
+	var output bytes.Buffer
+	unreachable := &Neo4jRepository{
+		client: driver,
+		config: &config.Neo4jConfig{},
+		logger: logging.New(&output, logging.LevelDebug),
+	}
+
+	_, err = unreachable.EntityExists(ctx, "logging-entity")
+	assert.NotNil(t, err, "Expected the query to fail without a connection")
+	assert.Contains(t, output.String(), "level=ERROR", "Expected the failure to be logged at ERROR level")
+	assert.Contains(t, output.String(), "entity_id=logging-entity", "Expected the entity Id field on the log line")
+}
+
+// TestTenantIsolation tests that entities created under one tenant are not returned when querying under another
+func TestTenantIsolation(t *testing.T) {
+	ctx := context.Background()
+	ctxA := WithTenant(ctx, "tenantA")
+	ctxB := WithTenant(ctx, "tenantB")
+
+	tenantA := &pb.Kind{Major: "Person", Minor: "Minister", Tenant: "tenantA"}
+	tenantB := &pb.Kind{Major: "Person", Minor: "Minister", Tenant: "tenantB"}
+
+	_, err := repository.CreateGraphEntity(ctxA, tenantA, map[string]interface{}{
+		"Id":      "tenant-a-1",
+		"Name":    "Alice",
+		"Created": "2024-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the tenant A entity")
+	_, err = repository.CreateGraphEntity(ctxB, tenantB, map[string]interface{}{
+		"Id":      "tenant-b-1",
+		"Name":    "Bob",
+		"Created": "2024-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the tenant B entity")
+
+	// An entity cannot be created for another tenant
+	_, err = repository.CreateGraphEntity(ctxB, tenantA, map[string]interface{}{
+		"Id":      "tenant-a-2",
+		"Name":    "Mallory",
+		"Created": "2024-01-01T00:00:00Z",
+	})
+	assert.NotNil(t, err, "Expected tenant B not to create a tenant A entity")
+
+	// The entity is stored under the tenant-prefixed label but read back with its plain major kind
+	entity, err := repository.ReadGraphEntity(ctxA, "tenant-a-1")
+	assert.Nil(t, err, "Expected no error when reading the tenant A entity")
+	assert.Equal(t, "Person", entity["MajorKind"])
+	assert.Equal(t, "tenantA", entity["Tenant"])
+
+	kind, _, _, _, err := repository.GetGraphEntity(ctxA, "tenant-a-1")
+	assert.Nil(t, err, "Expected no error when getting the tenant A entity")
+	assert.Equal(t, "tenantA", kind.Tenant)
+
+	// Reading by Id from another tenant or without one fails
+	_, err = repository.ReadGraphEntity(ctxB, "tenant-a-1")
+	assert.NotNil(t, err, "Expected tenant B not to read the tenant A entity")
+	_, err = repository.ReadGraphEntity(ctx, "tenant-a-1")
+	assert.NotNil(t, err, "Expected a shared read not to see the tenant A entity")
+	exists, err := repository.EntityExists(ctxB, "tenant-a-1")
+	assert.Nil(t, err, "Expected no error when checking the entity under tenant B")
+	assert.False(t, exists, "Expected the tenant A entity not to exist for tenant B")
+
+	// Relationships cannot link entities of different tenants
+	_, err = repository.CreateRelationship(ctxB, "tenant-b-1", &pb.Relationship{
+		Id:              "tenant-rel-1",
+		Name:            "KNOWS",
+		RelatedEntityId: "tenant-a-1",
+		StartTime:       "2024-01-01T00:00:00Z",
+	})
+	assert.NotNil(t, err, "Expected a cross-tenant relationship to be rejected")
+	relationships, err := repository.GetGraphRelationships(ctxA, "tenant-a-1")
+	assert.Nil(t, err, "Expected no error when reading the tenant A relationships")
+	assert.Empty(t, relationships, "Expected no relationship to reach the tenant A entity")
+
+	// Tenant B cannot change or delete the tenant A entity
+	_, err = repository.UpdateGraphEntity(ctxB, "tenant-a-1", map[string]interface{}{"Name": "Mallory"})
+	assert.NotNil(t, err, "Expected tenant B not to update the tenant A entity")
+	err = repository.DeleteGraphEntity(ctxB, "tenant-a-1")
+	assert.NotNil(t, err, "Expected tenant B not to delete the tenant A entity")
+
+	// Tenant A sees its entity
+	entities, err := repository.FilterEntities(ctxA, tenantA, map[string]interface{}{"id": "tenant-a-1"})
+	assert.Nil(t, err, "Expected no error when filtering under tenant A")
+	assert.Equal(t, 1, len(entities), "Expected tenant A to see its entity")
+	if len(entities) == 1 {
+		assert.Equal(t, "Person", entities[0]["kind"])
+		assert.Equal(t, "Alice", entities[0]["name"])
+	}
+
+	// Tenant B and the shared label do not
+	entities, err = repository.FilterEntities(ctxB, tenantB, map[string]interface{}{"id": "tenant-a-1"})
+	assert.Nil(t, err, "Expected no error when filtering under tenant B")
+	assert.Empty(t, entities, "Expected tenant B not to see the tenant A entity")
+
+	entities, err = repository.FilterEntities(ctx, &pb.Kind{Major: "Person"}, map[string]interface{}{"id": "tenant-a-1"})
+	assert.Nil(t, err, "Expected no error when filtering without a tenant")
+	assert.Empty(t, entities, "Expected shared queries not to see the tenant A entity")
+
+	// Filtering another tenant's kind is rejected
+	_, err = repository.FilterEntities(ctxB, tenantA, nil)
+	assert.NotNil(t, err, "Expected tenant B not to filter tenant A entities")
+
+	// Tenants that could break out of the label are rejected
+	badTenant := "a) DETACH DELETE (n"
+	_, err = repository.FilterEntities(WithTenant(ctx, badTenant), &pb.Kind{Major: "Person", Tenant: badTenant}, nil)
+	assert.NotNil(t, err, "Expected an invalid tenant to be rejected")
+}
+
+// TestTenantIsolationDatabaseNotImplemented tests that the DATABASE isolation mode is rejected until it is supported
+func TestTenantIsolationDatabaseNotImplemented(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Neo4jConfig{
+		URI:                 os.Getenv("NEO4J_URI"),
+		Username:            os.Getenv("NEO4J_USER"),
+		Password:            os.Getenv("NEO4J_PASSWORD"),
+		TenantIsolationMode: config.TenantIsolationDatabase,
+	}
+
+	_, err := NewNeo4jRepository(ctx, cfg, nil)
+	assert.ErrorIs(t, err, ErrNotImplemented)
+
+	cfg.TenantIsolationMode = "SCHEMA"
+	_, err = NewNeo4jRepository(ctx, cfg, nil)
+	assert.NotNil(t, err, "Expected an unknown isolation mode to be rejected")
+}
+
+// TestHandleGraphEntityCreationWithRelationshipsRollback tests that the entity node is rolled back when one of its relationships fails
+func TestHandleGraphEntityCreationWithRelationshipsRollback(t *testing.T) {
+	ctx := context.Background()
+
+	nameValue, err := anypb.New(wrapperspb.String("Rollback Person"))
+	assert.Nil(t, err)
+	entity := &pb.Entity{
+		Id:      "tx-rollback-1",
+		Kind:    &pb.Kind{Major: "Person", Minor: "Minister"},
+		Created: "2025-01-01T00:00:00Z",
+		Name:    &pb.TimeBasedValue{StartTime: "2025-01-01T00:00:00Z", Value: nameValue},
+		Relationships: map[string]*pb.Relationship{
+			"tx-rollback-rel-1": {
+				Id:              "tx-rollback-rel-1",
+				Name:            "KNOWS",
+				RelatedEntityId: "tx-missing-child",
+				StartTime:       "2025-01-01T00:00:00Z",
+			},
+		},
+	}
+
+	// The relationship points at a child that does not exist, so the whole transaction fails
+	success, err := repository.HandleGraphEntityCreationWithRelationships(ctx, entity)
+	assert.False(t, success)
+	assert.NotNil(t, err, "Expected an error when the child entity is missing")
+
+	exists, err := repository.EntityExists(ctx, "tx-rollback-1")
+	assert.Nil(t, err, "Expected no error when checking the entity")
+	assert.False(t, exists, "Expected the entity node to be rolled back")
+
+	// With an existing child both the node and the relationship are committed
+	_, err = repository.CreateGraphEntity(ctx, &pb.Kind{Major: "Person", Minor: "Minister"}, map[string]interface{}{
+		"Id":      "tx-existing-child",
+		"Name":    "Existing Child",
+		"Created": "2025-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the child entity")
+	entity.Relationships["tx-rollback-rel-1"].RelatedEntityId = "tx-existing-child"
+
+	success, err = repository.HandleGraphEntityCreationWithRelationships(ctx, entity)
+	assert.True(t, success)
+	assert.Nil(t, err, "Expected no error when the child entity exists")
+
+	relationship, err := repository.ReadRelationship(ctx, "tx-rollback-rel-1")
+	assert.Nil(t, err, "Expected the relationship to be committed")
+	assert.NotNil(t, relationship)
+}
+
+// TestCreateRelationshipsBatch tests that a batch creates the relationships to existing children and reports the missing one
+func TestCreateRelationshipsBatch(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+
+	// Create the parent and two of the three children
+	for _, entity := range []map[string]interface{}{
+		{"Id": "relbatch-parent", "Name": "Parent", "Created": "2024-01-01T00:00:00Z"},
+		{"Id": "relbatch-child-1", "Name": "Child One", "Created": "2024-01-01T00:00:00Z"},
+		{"Id": "relbatch-child-2", "Name": "Child Two", "Created": "2024-01-01T00:00:00Z"},
+	} {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating entity %s", entity["Id"])
+	}
+
+	rels := []*pb.Relationship{
+		{Id: "relbatch-rel-1", Name: "KNOWS", RelatedEntityId: "relbatch-child-1", StartTime: "2024-01-01T00:00:00Z"},
+		{Id: "relbatch-rel-2", Name: "ADVISES", RelatedEntityId: "relbatch-child-2", StartTime: "2024-01-01T00:00:00Z", EndTime: "2025-01-01T00:00:00Z"},
+		{Id: "relbatch-rel-3", Name: "KNOWS", RelatedEntityId: "relbatch-child-missing", StartTime: "2024-01-01T00:00:00Z"},
+		{Id: "relbatch-rel-4", Name: "KNOWS", RelatedEntityId: "relbatch-child-1", StartTime: ""},
+		{Id: "relbatch-rel-1", Name: "KNOWS", RelatedEntityId: "relbatch-child-2", StartTime: "2024-01-01T00:00:00Z"},
+	}
+	created, errs := repository.CreateRelationshipsBatch(ctx, "relbatch-parent", rels)
+	assert.Equal(t, len(rels), len(created))
+	assert.Equal(t, len(rels), len(errs))
+
+	// The relationships to existing children succeed
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, errs[i], "Expected relationship %s to be created", rels[i].Id)
+		if assert.NotNil(t, created[i]) {
+			assert.Equal(t, rels[i].Id, created[i]["Id"])
+			assert.Equal(t, rels[i].Name, created[i]["relationshipType"])
+		}
+	}
+	assert.Equal(t, "2025-01-01T00:00:00Z", created[1]["Terminated"])
+
+	// The relationship to the missing child fails on its own
+	assert.Nil(t, created[2])
+	if assert.NotNil(t, errs[2], "Expected an error for the missing child") {
+		assert.Contains(t, errs[2].Error(), "relbatch-child-missing")
+	}
+
+	// A missing start time and a repeated Id fail on their own instead of aborting the batch
+	assert.Nil(t, created[3])
+	if assert.NotNil(t, errs[3], "Expected an error for the missing start time") {
+		assert.Contains(t, errs[3].Error(), "invalid start time")
+	}
+	assert.Nil(t, created[4])
+	if assert.NotNil(t, errs[4], "Expected an error for the duplicated Id") {
+		assert.Contains(t, errs[4].Error(), "duplicated")
+	}
+
+	relationships, err := repository.ReadRelationships(ctx, "relbatch-parent")
+	assert.Nil(t, err, "Expected no error when reading the relationships")
+	assert.Equal(t, 2, len(relationships), "Expected only the two valid relationships to be stored")
+}
+
+// TestSoftDeleteGraphEntity verifies that soft-deleted entities are kept but hidden from default queries:
+// 1. Creates an entity and soft-deletes it with a past date
+// 2. Confirms FilterEntities no longer returns it by default
+// 3. Confirms it is returned again when include_terminated is set and can still be read
+// 4. Confirms soft-deleting a missing entity fails
+func TestSoftDeleteGraphEntity(t *testing.T) {
+	ctx := context.Background()
+	kind := &pb.Kind{Major: "Person", Minor: "Citizen"}
+
+	_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+		"Id":      "soft-delete-1",
+		"Name":    "Nimal Silva",
+		"Created": "2025-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating entity")
+
+	entities, err := repository.FilterEntities(ctx, kind, map[string]interface{}{"id": "soft-delete-1"})
+	assert.Nil(t, err)
+	assert.Len(t, entities, 1, "Expected the entity before it is soft-deleted")
+
+	err = repository.SoftDeleteGraphEntity(ctx, "soft-delete-1", "2025-06-01T00:00:00Z")
+	assert.Nil(t, err, "Expected no error when soft-deleting entity")
+
+	entities, err = repository.FilterEntities(ctx, kind, map[string]interface{}{"id": "soft-delete-1"})
+	assert.Nil(t, err)
+	assert.Empty(t, entities, "Expected the soft-deleted entity to be hidden by default")
+
+	entities, err = repository.FilterEntities(ctx, kind, map[string]interface{}{"id": "soft-delete-1", "include_terminated": true})
+	assert.Nil(t, err)
+	if assert.Len(t, entities, 1, "Expected the soft-deleted entity with include_terminated") {
+		assert.Contains(t, entities[0]["terminated"], "2025-06-01", "Expected the Terminated date to be set")
+	}
+
+	readEntity, err := repository.ReadGraphEntity(ctx, "soft-delete-1")
+	assert.Nil(t, err, "Expected the soft-deleted entity to still be readable")
+	assert.Equal(t, "soft-delete-1", readEntity["Id"])
+
+	err = repository.SoftDeleteGraphEntity(ctx, "soft-delete-missing", "2025-06-01T00:00:00Z")
+	assert.NotNil(t, err, "Expected an error when soft-deleting a missing entity")
+}
+
+// TestCreateGraphEntityWithLabels verifies that an entity can carry labels next to its kind label:
+// 1. Creates a Person entity that is also labelled Employee
+// 2. Reads it back and confirms both labels are returned and the major kind is still Person
+// 3. Confirms it can be matched by the additional label
+// 4. Confirms a label that is not a plain identifier is rejected
+func TestCreateGraphEntityWithLabels(t *testing.T) {
+	ctx := context.Background()
+	kind := &pb.Kind{Major: "Person", Minor: "Staff"}
+
+	created, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+		"Id":      "labels-1",
+		"Name":    "Kamala Fernando",
+		"Created": "2025-01-01T00:00:00Z",
+		"Labels":  []string{"Employee"},
+	})
+	assert.Nil(t, err, "Expected no error when creating a two-label entity")
+	assert.ElementsMatch(t, []string{"Person", "Employee"}, created["Labels"])
+
+	entity, err := repository.ReadGraphEntity(ctx, "labels-1")
+	assert.Nil(t, err, "Expected no error when reading the two-label entity")
+	assert.ElementsMatch(t, []string{"Person", "Employee"}, entity["Labels"], "Expected both labels to be returned")
+	assert.Equal(t, "Person", entity["MajorKind"], "Expected the major kind to remain Person")
+
+	entities, err := repository.FilterEntities(ctx, &pb.Kind{Major: "Employee"}, map[string]interface{}{"id": "labels-1"})
+	assert.Nil(t, err)
+	if assert.Len(t, entities, 1, "Expected the entity to match its additional label") {
+		assert.Equal(t, "Person", entities[0]["kind"])
+	}
+
+	_, err = repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+		"Id":      "labels-2",
+		"Name":    "Injected",
+		"Created": "2025-01-01T00:00:00Z",
+		"Labels":  []string{"Employee) DETACH DELETE (n"},
+	})
+	assert.NotNil(t, err, "Expected an unsafe label to be rejected")
+}
+
+// TestFindShortestPath tests finding the shortest path between entities
+// 1. Builds the chain path-a -> path-b -> path-c -> path-d and an unconnected path-e
+// 2. Finds the direct one hop path and the indirect three hop path
+// 3. Verifies ErrNoPath when nothing connects the entities or the type filter excludes the only path
+func TestFindShortestPath(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{Major: "Person", Minor: "Staff"}
+	for _, id := range []string{"path-a", "path-b", "path-c", "path-d", "path-e"} {
+		_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+			"Id": id, "Name": "Person " + id, "Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating entity %s", id)
+	}
+	for _, link := range []struct{ parent, child, id, name string }{
+		{"path-a", "path-b", "path-rel-ab", "REPORTS_TO"},
+		{"path-b", "path-c", "path-rel-bc", "REPORTS_TO"},
+		{"path-c", "path-d", "path-rel-cd", "MENTORS"},
+	} {
+		_, err := repository.CreateRelationship(ctx, link.parent, &pb.Relationship{
+			Id:              link.id,
+			Name:            link.name,
+			RelatedEntityId: link.child,
+			StartTime:       "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating relationship %s", link.id)
+	}
+
+	// Direct connection
+	path, err := repository.FindShortestPath(ctx, "path-a", "path-b", nil, 5)
+	assert.Nil(t, err, "Expected no error when finding a one hop path")
+	if assert.Len(t, path, 2, "Expected both ends of the one hop path") {
+		assert.Equal(t, "path-a", path[0]["Id"])
+		assert.Nil(t, path[0]["RelationshipType"], "Expected no relationship type on the start of the path")
+		assert.Equal(t, "path-b", path[1]["Id"])
+		assert.Equal(t, "REPORTS_TO", path[1]["RelationshipType"])
+		assert.Equal(t, "Person", path[1]["MajorKind"])
+	}
+
+	// Indirect connection
+	path, err = repository.FindShortestPath(ctx, "path-a", "path-d", nil, 5)
+	assert.Nil(t, err, "Expected no error when finding a three hop path")
+	if assert.Len(t, path, 4, "Expected every entity along the three hop path") {
+		ids := []string{}
+		types := []interface{}{}
+		for _, node := range path[1:] {
+			ids = append(ids, node["Id"].(string))
+			types = append(types, node["RelationshipType"])
+		}
+		assert.Equal(t, []string{"path-b", "path-c", "path-d"}, ids)
+		assert.Equal(t, []interface{}{"REPORTS_TO", "REPORTS_TO", "MENTORS"}, types)
+	}
+
+	// The path is longer than the allowed hops
+	_, err = repository.FindShortestPath(ctx, "path-a", "path-d", nil, 2)
+	assert.ErrorIs(t, err, ErrNoPath, "Expected no path within two hops")
+
+	// No path, and relationships are only followed outwards
+	_, err = repository.FindShortestPath(ctx, "path-a", "path-e", nil, 5)
+	assert.ErrorIs(t, err, ErrNoPath, "Expected no path to an unconnected entity")
+	_, err = repository.FindShortestPath(ctx, "path-d", "path-a", nil, 5)
+	assert.ErrorIs(t, err, ErrNoPath, "Expected no path against the relationship direction")
+
+	// The type filter removes the only path
+	_, err = repository.FindShortestPath(ctx, "path-a", "path-d", []string{"REPORTS_TO"}, 5)
+	assert.ErrorIs(t, err, ErrNoPath, "Expected no path when the filter excludes MENTORS")
+	path, err = repository.FindShortestPath(ctx, "path-a", "path-c", []string{"REPORTS_TO"}, 5)
+	assert.Nil(t, err, "Expected the filter to keep REPORTS_TO paths")
+	assert.Len(t, path, 3)
+
+	// Unsafe relationship types and lengths are rejected
+	_, err = repository.FindShortestPath(ctx, "path-a", "path-b", []string{"REPORTS_TO]->() DETACH DELETE (n"}, 5)
+	assert.NotNil(t, err, "Expected an unsafe relationship type to be rejected")
+	assert.NotErrorIs(t, err, ErrNoPath)
+	_, err = repository.FindShortestPath(ctx, "path-a", "path-b", nil, MaxTraversalDepth+1)
+	assert.NotNil(t, err, "Expected a path length above the maximum to be rejected")
+}
+
+// TestReadNeighborhood tests reading an entity with its neighbors up to a depth
+// 1. Builds the chain hood-a -> hood-b -> hood-c
+// 2. Verifies depth 1 from hood-a returns hood-a and hood-b with one relationship
+// 3. Verifies depth 2 returns the whole chain, each node and relationship once
+func TestReadNeighborhood(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{Major: "Organisation", Minor: "Department"}
+	for _, id := range []string{"hood-a", "hood-b", "hood-c"} {
+		_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+			"Id": id, "Name": "Department " + id, "Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating entity %s", id)
+	}
+	for _, link := range []struct{ parent, child, id string }{
+		{"hood-a", "hood-b", "hood-rel-ab"},
+		{"hood-b", "hood-c", "hood-rel-bc"},
+	} {
+		_, err := repository.CreateRelationship(ctx, link.parent, &pb.Relationship{
+			Id:              link.id,
+			Name:            "HAS_UNIT",
+			RelatedEntityId: link.child,
+			StartTime:       "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating relationship %s", link.id)
+	}
+
+	ids := func(items []map[string]interface{}) []string {
+		out := []string{}
+		for _, item := range items {
+			out = append(out, item["Id"].(string))
+		}
+		return out
+	}
+
+	nodes, relationships, err := repository.ReadNeighborhood(ctx, "hood-a", 1)
+	assert.Nil(t, err, "Expected no error when reading a depth 1 neighborhood")
+	assert.ElementsMatch(t, []string{"hood-a", "hood-b"}, ids(nodes))
+	assert.ElementsMatch(t, []string{"hood-rel-ab"}, ids(relationships))
+
+	nodes, relationships, err = repository.ReadNeighborhood(ctx, "hood-a", 2)
+	assert.Nil(t, err, "Expected no error when reading a depth 2 neighborhood")
+	assert.ElementsMatch(t, []string{"hood-a", "hood-b", "hood-c"}, ids(nodes))
+	assert.ElementsMatch(t, []string{"hood-rel-ab", "hood-rel-bc"}, ids(relationships))
+	for _, rel := range relationships {
+		if rel["Id"] == "hood-rel-bc" {
+			assert.Equal(t, "hood-b", rel["StartEntityId"], "Expected the relationship direction to be kept")
+			assert.Equal(t, "hood-c", rel["EndEntityId"])
+			assert.Equal(t, "HAS_UNIT", rel["Name"])
+		}
+	}
+
+	// Relationships are followed in both directions
+	nodes, _, err = repository.ReadNeighborhood(ctx, "hood-c", 1)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"hood-c", "hood-b"}, ids(nodes))
+
+	_, _, err = repository.ReadNeighborhood(ctx, "hood-a", DefaultMaxNeighborhoodDepth+1)
+	assert.NotNil(t, err, "Expected a depth above the maximum to be rejected")
+	_, _, err = repository.ReadNeighborhood(ctx, "hood-missing", 1)
+	assert.NotNil(t, err, "Expected an error for a missing entity")
+}
+
+// TestSessionPoolConcurrentReads tests the session pool under concurrent use; run it with -race to check for data races
+// 1. Creates a repository limited to 5 concurrent sessions
+// 2. Reads the same entity from 50 goroutines at once
+// 3. Verifies every read succeeds and no more than 5 sessions are kept open afterwards
+func TestSessionPoolConcurrentReads(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Neo4jConfig{
+		URI:                   os.Getenv("NEO4J_URI"),
+		Username:              os.Getenv("NEO4J_USER"),
+		Password:              os.Getenv("NEO4J_PASSWORD"),
+		MaxConcurrentSessions: 5,
+	}
+	pooledRepository, err := NewNeo4jRepository(ctx, cfg, nil)
+	if !assert.Nil(t, err, "Expected no error when creating a repository with a session limit") {
+		return
+	}
+	defer pooledRepository.Close(ctx)
+
+	_, err = pooledRepository.CreateGraphEntity(ctx, &pb.Kind{Major: "Person", Minor: "Staff"}, map[string]interface{}{
+		"Id": "session-pool-1", "Name": "Nimal Silva", "Created": "2025-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the entity")
+
+	const readers = 50
+	errs := make(chan error, readers)
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entity, err := pooledRepository.ReadGraphEntity(ctx, "session-pool-1")
+			if err == nil && entity["Name"] != "Nimal Silva" {
+				err = fmt.Errorf("unexpected entity %v", entity)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.Nil(t, err, "Expected every concurrent read to succeed")
+	}
+
+	pool := pooledRepository.sessions()
+	assert.Equal(t, 0, len(pool.slots), "Expected every session to be released")
+	assert.LessOrEqual(t, len(pool.idle), 5, "Expected at most the session limit to be kept open")
+}
+
+// TestAcquireSessionBlocksWhenExhausted tests that AcquireSession waits for a free session and honours cancellation
+func TestAcquireSessionBlocksWhenExhausted(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Neo4jConfig{
+		URI:                   os.Getenv("NEO4J_URI"),
+		Username:              os.Getenv("NEO4J_USER"),
+		Password:              os.Getenv("NEO4J_PASSWORD"),
+		MaxConcurrentSessions: 1,
+	}
+	pooledRepository, err := NewNeo4jRepository(ctx, cfg, nil)
+	if !assert.Nil(t, err, "Expected no error when creating a repository with a session limit") {
+		return
+	}
+	defer pooledRepository.Close(ctx)
+
+	session, err := pooledRepository.AcquireSession(ctx)
+	assert.Nil(t, err, "Expected the first session to be acquired")
+
+	// The only session is in use, so the next acquire waits until the context expires
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = pooledRepository.AcquireSession(waitCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected the acquire to fail when the context expires")
+
+	// Releasing the session makes it available again, and the open session is reused
+	pooledRepository.ReleaseSession(ctx, session)
+	reused, err := pooledRepository.AcquireSession(ctx)
+	assert.Nil(t, err, "Expected the released session to be acquired")
+	assert.Same(t, session.(*pooledSession).SessionWithContext, reused.(*pooledSession).SessionWithContext, "Expected the idle session to be reused")
+	reused.Close(ctx)
+
+	// Releasing twice does not free a second slot
+	reused.Close(ctx)
+	assert.Equal(t, 0, len(pooledRepository.sessions().slots))
+}
+
+// TestExportAdjacencyMatrix tests exporting the adjacency matrix of a kind
+// 1. Builds a star with one hub connected to 5 leaves, plus relationships that must be left out
+// 2. Verifies the hub's row has exactly 5 non-zero entries, one per leaf
+// 3. Verifies the leaves' rows are empty
+func TestExportAdjacencyMatrix(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{Major: "Organisation", Minor: "AdjacencyStar"}
+	ids := []string{"star-hub", "star-leaf-1", "star-leaf-2", "star-leaf-3", "star-leaf-4", "star-leaf-5"}
+	for _, id := range ids {
+		_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+			"Id": id, "Name": "Organisation " + id, "Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating entity %s", id)
+	}
+	_, err := repository.CreateGraphEntity(ctx, &pb.Kind{Major: "Organisation", Minor: "Department"}, map[string]interface{}{
+		"Id": "star-outside", "Name": "Outside the star", "Created": "2025-01-01T00:00:00Z",
+	})
+	assert.Nil(t, err, "Expected no error when creating the entity of another kind")
+
+	links := []struct{ parent, child, id, name string }{
+		// A relationship of another type and one to an entity of another kind are not exported
+		{"star-leaf-1", "star-leaf-2", "star-rel-other-type", "PARTNERS_WITH"},
+		{"star-hub", "star-outside", "star-rel-outside", "HAS_BRANCH"},
+	}
+	for i, leaf := range ids[1:] {
+		links = append(links, struct{ parent, child, id, name string }{"star-hub", leaf, fmt.Sprintf("star-rel-%d", i+1), "HAS_BRANCH"})
+	}
+	for _, link := range links {
+		_, err := repository.CreateRelationship(ctx, link.parent, &pb.Relationship{
+			Id:              link.id,
+			Name:            link.name,
+			RelatedEntityId: link.child,
+			StartTime:       "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating relationship %s", link.id)
+	}
+
+	matrix, labels, err := repository.ExportAdjacencyMatrix(ctx, kind, "HAS_BRANCH")
+	assert.Nil(t, err, "Expected no error when exporting the adjacency matrix")
+	assert.ElementsMatch(t, ids, labels, "Expected only the entities of the kind to label the matrix")
+	if !assert.Len(t, matrix, len(labels), "Expected a row per entity") {
+		return
+	}
+
+	for i, id := range labels {
+		assert.Len(t, matrix[i], len(labels), "Expected the matrix to be square")
+		nonZero := 0
+		for _, value := range matrix[i] {
+			nonZero += value
+		}
+		if id == "star-hub" {
+			assert.Equal(t, 5, nonZero, "Expected the hub to be connected to the 5 leaves")
+		} else {
+			assert.Equal(t, 0, nonZero, "Expected leaf %s to have no outgoing HAS_BRANCH relationships", id)
+		}
+	}
+
+	_, _, err = repository.ExportAdjacencyMatrix(ctx, kind, "HAS_BRANCH]->() DETACH DELETE (n")
+	assert.NotNil(t, err, "Expected an unsafe relationship type to be rejected")
+}
+
+// TestReadRelationshipsDirection tests reading the relationships of an entity in one direction
+// 1. Creates dir-middle with one incoming relationship from dir-parent and one outgoing relationship to dir-child
+// 2. Verifies the outgoing direction returns only the relationship to dir-child
+// 3. Verifies the incoming direction returns only the relationship from dir-parent, and ReadRelationships returns both
+func TestReadRelationshipsDirection(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{Major: "Organisation", Minor: "Department"}
+	for _, id := range []string{"dir-parent", "dir-middle", "dir-child"} {
+		_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+			"Id": id, "Name": "Department " + id, "Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating entity %s", id)
+	}
+	for _, link := range []struct{ parent, child, id string }{
+		{"dir-parent", "dir-middle", "dir-rel-in"},
+		{"dir-middle", "dir-child", "dir-rel-out"},
+	} {
+		_, err := repository.CreateRelationship(ctx, link.parent, &pb.Relationship{
+			Id:              link.id,
+			Name:            "HAS_UNIT",
+			RelatedEntityId: link.child,
+			StartTime:       "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating relationship %s", link.id)
+	}
+
+	relationships, err := repository.ReadRelationshipsOfTypes(ctx, "dir-middle", nil, DirectionOutgoing)
+	assert.Nil(t, err, "Expected no error when reading outgoing relationships")
+	if assert.Len(t, relationships, 1, "Expected only the outgoing relationship") {
+		assert.Equal(t, "dir-rel-out", relationships[0]["relationshipID"])
+		assert.Equal(t, "dir-child", relationships[0]["relatedID"])
+		assert.Equal(t, "OUTGOING", relationships[0]["direction"])
+	}
+
+	relationships, err = repository.ReadRelationshipsOfTypes(ctx, "dir-middle", nil, DirectionIncoming)
+	assert.Nil(t, err, "Expected no error when reading incoming relationships")
+	if assert.Len(t, relationships, 1, "Expected only the incoming relationship") {
+		assert.Equal(t, "dir-rel-in", relationships[0]["relationshipID"])
+		assert.Equal(t, "dir-parent", relationships[0]["relatedID"])
+		assert.Equal(t, "INCOMING", relationships[0]["direction"])
+	}
+
+	relationships, err = repository.ReadRelationships(ctx, "dir-middle")
+	assert.Nil(t, err, "Expected no error when reading relationships in both directions")
+	assert.Len(t, relationships, 2, "Expected both relationships by default")
+}
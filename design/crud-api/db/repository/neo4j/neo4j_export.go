@@ -0,0 +1,183 @@
+package neo4jrepository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+)
+
+// MaxAdjacencyMatrixNodes is the largest number of entities ExportAdjacencyMatrix will export
+const MaxAdjacencyMatrixNodes = 1000
+
+// ErrGraphTooLarge is returned by ExportAdjacencyMatrix when the kind has more than MaxAdjacencyMatrixNodes entities
+var ErrGraphTooLarge = errors.New("graph too large to export")
+
+// DefaultJSONLDVocab is the vocabulary used for JSON-LD terms that ContextMap does not map
+const DefaultJSONLDVocab = "https://datafoundation.lk/vocab#"
+
+// ExportToJSONLD serialises the subgraph reachable from the root entity within depth outgoing hops as a JSON-LD document.
+// Entities become @type annotated nodes in @graph and relationships between exported entities become predicates
+// named after the relationship type. Kinds and relationship names are mapped to IRIs through ContextMap.
+func (r *Neo4jRepository) ExportToJSONLD(ctx context.Context, rootEntityID string, depth int) ([]byte, error) {
+	root, err := r.ReadGraphEntity(ctx, rootEntityID)
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.ExportToJSONLD] error reading root entity %s: %v", rootEntityID, err)
+		return nil, fmt.Errorf("error reading root entity %s: %v", rootEntityID, err)
+	}
+
+	reachable, err := r.TraverseGraph(ctx, rootEntityID, "", depth, "")
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.ExportToJSONLD] error traversing from %s: %v", rootEntityID, err)
+		return nil, fmt.Errorf("error traversing from %s: %v", rootEntityID, err)
+	}
+	entities := append([]map[string]interface{}{root}, reachable...)
+
+	exported := make(map[string]bool, len(entities))
+	for _, entity := range entities {
+		exported[fmt.Sprintf("%v", entity["Id"])] = true
+	}
+
+	graph := make([]map[string]interface{}, 0, len(entities))
+	for _, entity := range entities {
+		id := fmt.Sprintf("%v", entity["Id"])
+		node := map[string]interface{}{
+			"@id":       id,
+			"@type":     entity["MajorKind"],
+			"name":      entity["Name"],
+			"minorKind": entity["MinorKind"],
+			"created":   entity["Created"],
+		}
+		if terminated, ok := entity["Terminated"]; ok {
+			node["terminated"] = terminated
+		}
+
+		// Only relationships between exported entities are included so the document is self-contained
+		relationships, err := r.ReadRelationshipsOfTypes(ctx, id, nil, DirectionOutgoing)
+		if err != nil {
+			r.logger.Errorf("[neo4j_client.ExportToJSONLD] error reading relationships of %s: %v", id, err)
+			return nil, fmt.Errorf("error reading relationships of %s: %v", id, err)
+		}
+		for _, rel := range relationships {
+			relatedID := fmt.Sprintf("%v", rel["relatedID"])
+			if !exported[relatedID] {
+				continue
+			}
+			predicate := fmt.Sprintf("%v", rel["type"])
+			targets, _ := node[predicate].([]map[string]interface{})
+			node[predicate] = append(targets, map[string]interface{}{"@id": relatedID})
+		}
+
+		graph = append(graph, node)
+	}
+
+	jsonLDContext := map[string]interface{}{
+		"@vocab": DefaultJSONLDVocab,
+	}
+	for term, iri := range r.ContextMap {
+		jsonLDContext[term] = iri
+	}
+
+	document := map[string]interface{}{
+		"@context": jsonLDContext,
+		"@graph":   graph,
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.ExportToJSONLD] error encoding JSON-LD: %v", err)
+		return nil, fmt.Errorf("error encoding JSON-LD: %v", err)
+	}
+	return data, nil
+}
+
+// ExportAdjacencyMatrix returns the adjacency matrix of the entities of the given kind together with their Ids, sorted,
+// which label both the rows and the columns. matrix[i][j] is 1 when entity i has an outgoing relationship of the given
+// type to entity j, and 0 otherwise. An empty relationshipType includes relationships of every type.
+func (r *Neo4jRepository) ExportAdjacencyMatrix(ctx context.Context, kind *pb.Kind, relationshipType string) ([][]int, []string, error) {
+	if kind == nil || kind.Major == "" {
+		return nil, nil, fmt.Errorf("kind.Major is required")
+	}
+	label, err := tenantKindLabel(ctx, kind)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Labels and relationship types cannot be query parameters, so they are validated and formatted into the patterns
+	if !labelPattern.MatchString(label) {
+		return nil, nil, fmt.Errorf("invalid kind %q: only letters, digits and '_' are allowed", kind.Major)
+	}
+	typePattern := ""
+	if relationshipType != "" {
+		if !labelPattern.MatchString(relationshipType) {
+			return nil, nil, fmt.Errorf("invalid relationship type %q: only letters, digits and '_' are allowed", relationshipType)
+		}
+		typePattern = ":" + relationshipType
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	params := map[string]interface{}{
+		"limit": MaxAdjacencyMatrixNodes + 1,
+	}
+	minorFilter := ""
+	if kind.Minor != "" {
+		minorFilter = `WHERE e.MinorKind = $minorKind`
+		params["minorKind"] = kind.Minor
+	}
+
+	// Fetch one Id past the limit to tell whether the graph is too large
+	result, err := r.run(ctx, session, "ExportAdjacencyMatrix", `
+        MATCH (e:`+label+`) `+minorFilter+`
+        RETURN e.Id AS Id
+        ORDER BY Id
+        LIMIT $limit`, params)
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.ExportAdjacencyMatrix] error reading entities: %v", err)
+		return nil, nil, fmt.Errorf("error reading entities: %v", err)
+	}
+	var ids []string
+	index := make(map[string]int)
+	for result.Next(ctx) {
+		id, _ := result.Record().Get("Id")
+		index[fmt.Sprintf("%v", id)] = len(ids)
+		ids = append(ids, fmt.Sprintf("%v", id))
+	}
+	if err := result.Err(); err != nil {
+		r.logger.Errorf("[neo4j_client.ExportAdjacencyMatrix] error iterating over entities: %v", err)
+		return nil, nil, fmt.Errorf("error iterating over entities: %v", err)
+	}
+	if len(ids) > MaxAdjacencyMatrixNodes {
+		return nil, nil, fmt.Errorf("%w: more than %d %s entities", ErrGraphTooLarge, MaxAdjacencyMatrixNodes, kind.Major)
+	}
+
+	matrix := make([][]int, len(ids))
+	for i := range matrix {
+		matrix[i] = make([]int, len(ids))
+	}
+	if len(ids) == 0 {
+		return matrix, ids, nil
+	}
+
+	result, err = r.run(ctx, session, "ExportAdjacencyMatrix", `
+        MATCH (a:`+label+`)-[`+typePattern+`]->(b:`+label+`)
+        WHERE a.Id IN $ids AND b.Id IN $ids
+        RETURN DISTINCT a.Id AS source, b.Id AS target`, map[string]interface{}{"ids": ids})
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.ExportAdjacencyMatrix] error reading relationships: %v", err)
+		return nil, nil, fmt.Errorf("error reading relationships: %v", err)
+	}
+	for result.Next(ctx) {
+		record := result.Record()
+		source, _ := record.Get("source")
+		target, _ := record.Get("target")
+		matrix[index[fmt.Sprintf("%v", source)]][index[fmt.Sprintf("%v", target)]] = 1
+	}
+	if err := result.Err(); err != nil {
+		r.logger.Errorf("[neo4j_client.ExportAdjacencyMatrix] error iterating over relationships: %v", err)
+		return nil, nil, fmt.Errorf("error iterating over relationships: %v", err)
+	}
+
+	return matrix, ids, nil
+}
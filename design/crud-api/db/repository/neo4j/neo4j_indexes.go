@@ -0,0 +1,96 @@
+package neo4jrepository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// nameIndexAwaitSeconds bounds how long index creation waits for a new full-text index to come online
+const nameIndexAwaitSeconds = 60
+
+// nameIndexName returns the name of the full-text index over the Name property of a label
+func nameIndexName(label string) string {
+	return "entity_name_" + strings.ToLower(label)
+}
+
+// EnsureIndexes creates the full-text Name index for every entity label currently in the database.
+// It is idempotent and is also run by NewNeo4jRepository; indexes for labels created later are added on first search.
+func (r *Neo4jRepository) EnsureIndexes(ctx context.Context) error {
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	result, err := r.run(ctx, session, "EnsureIndexes", `CALL db.labels() YIELD label RETURN label`, nil)
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.EnsureIndexes] error listing labels: %v", err)
+		return fmt.Errorf("error listing labels: %v", err)
+	}
+
+	var labels []string
+	for result.Next(ctx) {
+		if label, ok := result.Record().Values[0].(string); ok {
+			labels = append(labels, label)
+		}
+	}
+	if err := result.Err(); err != nil {
+		r.logger.Errorf("[neo4j_client.EnsureIndexes] error iterating over labels: %v", err)
+		return fmt.Errorf("error iterating over labels: %v", err)
+	}
+
+	for _, label := range labels {
+		if err := r.ensureNameIndex(ctx, session, label); err != nil {
+			return err
+		}
+	}
+
+	r.logger.Infof("[neo4j_client.EnsureIndexes] ensured full-text name indexes for %d labels", len(labels))
+	return nil
+}
+
+// ensureNameIndex creates the full-text Name index for a label if it does not exist and waits for it to come online
+func (r *Neo4jRepository) ensureNameIndex(ctx context.Context, session neo4j.SessionWithContext, label string) error {
+	if _, ok := r.nameIndexes.Load(label); ok {
+		return nil
+	}
+
+	indexName := nameIndexName(label)
+	createQuery := fmt.Sprintf("CREATE FULLTEXT INDEX `%s` IF NOT EXISTS FOR (e:`%s`) ON EACH [e.Name]", indexName, label)
+	if _, err := r.run(ctx, session, "EnsureIndexes", createQuery, nil); err != nil {
+		r.logger.Errorf("[neo4j_client.ensureNameIndex] error creating full-text index %s: %v", indexName, err)
+		return fmt.Errorf("error creating full-text index %s: %v", indexName, err)
+	}
+
+	awaitQuery := `CALL db.awaitIndex($indexName, $timeout)`
+	result, err := r.run(ctx, session, "EnsureIndexes", awaitQuery, map[string]interface{}{
+		"indexName": indexName,
+		"timeout":   nameIndexAwaitSeconds,
+	})
+	if err == nil {
+		_, err = result.Consume(ctx)
+	}
+	if err != nil {
+		r.logger.Errorf("[neo4j_client.ensureNameIndex] error waiting for full-text index %s: %v", indexName, err)
+		return fmt.Errorf("error waiting for full-text index %s: %v", indexName, err)
+	}
+
+	r.nameIndexes.Store(label, struct{}{})
+	return nil
+}
+
+// nameSearchQuery builds a Lucene query matching names that contain every word of the search text as a word prefix
+func nameSearchQuery(search string) string {
+	var terms []string
+	for _, word := range strings.Fields(strings.ToLower(search)) {
+		var escaped strings.Builder
+		for _, c := range word {
+			if strings.ContainsRune(`+-&|!(){}[]^"~*?:\/`, c) {
+				escaped.WriteRune('\\')
+			}
+			escaped.WriteRune(c)
+		}
+		terms = append(terms, escaped.String()+"*")
+	}
+	return strings.Join(terms, " AND ")
+}
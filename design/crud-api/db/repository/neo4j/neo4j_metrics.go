@@ -0,0 +1,100 @@
+package neo4jrepository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// MetricsRecorder receives one observation per query run by the repository, labeled by repository method
+type MetricsRecorder interface {
+	ObserveQuery(method string, duration time.Duration, err error)
+}
+
+// QueryStats holds the aggregated metrics recorded for a single repository method
+type QueryStats struct {
+	Count        int
+	Errors       int
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// QueryMetrics is an in-memory MetricsRecorder that aggregates query counts, errors and latencies per method
+type QueryMetrics struct {
+	mu    sync.Mutex
+	stats map[string]QueryStats
+}
+
+// NewQueryMetrics creates an empty QueryMetrics registry
+func NewQueryMetrics() *QueryMetrics {
+	return &QueryMetrics{stats: make(map[string]QueryStats)}
+}
+
+// ObserveQuery records a single query run for the given method
+func (m *QueryMetrics) ObserveQuery(method string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.stats[method]
+	stats.Count++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.TotalLatency += duration
+	if duration > stats.MaxLatency {
+		stats.MaxLatency = duration
+	}
+	m.stats[method] = stats
+}
+
+// Snapshot returns a copy of the metrics recorded so far, keyed by method
+func (m *QueryMetrics) Snapshot() map[string]QueryStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]QueryStats, len(m.stats))
+	for method, stats := range m.stats {
+		snapshot[method] = stats
+	}
+	return snapshot
+}
+
+// SetMetricsRecorder enables query metrics for the repository; passing nil disables them
+func (r *Neo4jRepository) SetMetricsRecorder(recorder MetricsRecorder) {
+	r.metrics = recorder
+}
+
+// run executes a query in the session, traced as a span, and records its latency and outcome under the given method name.
+// When no recorder is set the query is run without timing.
+func (r *Neo4jRepository) run(ctx context.Context, session neo4j.SessionWithContext, method string, query string, params map[string]interface{}) (neo4j.ResultWithContext, error) {
+	ctx, span := startSpan(ctx, method, query, params)
+	if r.metrics == nil {
+		result, err := r.runQuery(ctx, session, query, params)
+		endSpan(span, err)
+		return result, err
+	}
+
+	start := time.Now()
+	result, err := r.runQuery(ctx, session, query, params)
+	r.metrics.ObserveQuery(method, time.Since(start), err)
+	endSpan(span, err)
+	return result, err
+}
+
+// runTx executes a query in a managed transaction, traced as a span, and records its latency and outcome under the given method name
+func (r *Neo4jRepository) runTx(ctx context.Context, tx neo4j.ManagedTransaction, method string, query string, params map[string]interface{}) (neo4j.ResultWithContext, error) {
+	ctx, span := startSpan(ctx, method, query, params)
+	if r.metrics == nil {
+		result, err := tx.Run(ctx, query, params)
+		endSpan(span, err)
+		return result, err
+	}
+
+	start := time.Now()
+	result, err := tx.Run(ctx, query, params)
+	r.metrics.ObserveQuery(method, time.Since(start), err)
+	endSpan(span, err)
+	return result, err
+}
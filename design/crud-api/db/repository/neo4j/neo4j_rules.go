@@ -0,0 +1,37 @@
+package neo4jrepository
+
+import (
+	"fmt"
+
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+)
+
+// RelationshipRule allows relationships of Type from entities of kind From to entities of kind To.
+// An empty Minor in From or To matches every minor kind of that major kind.
+type RelationshipRule struct {
+	Type string
+	From *pb.Kind
+	To   *pb.Kind
+}
+
+// kindMatches reports whether an entity kind satisfies the kind named in a rule
+func kindMatches(rule *pb.Kind, major, minor string) bool {
+	if rule == nil {
+		return true
+	}
+	return rule.Major == major && (rule.Minor == "" || rule.Minor == minor)
+}
+
+// checkRelationshipRules returns an error unless a rule allows a relationship of relType between the given kinds.
+// Every combination is allowed when no rules are configured.
+func (r *Neo4jRepository) checkRelationshipRules(relType string, fromMajor, fromMinor, toMajor, toMinor string) error {
+	if len(r.RelationshipRules) == 0 {
+		return nil
+	}
+	for _, rule := range r.RelationshipRules {
+		if rule.Type == relType && kindMatches(rule.From, fromMajor, fromMinor) && kindMatches(rule.To, toMajor, toMinor) {
+			return nil
+		}
+	}
+	return fmt.Errorf("relationship %s is not allowed from %s/%s to %s/%s", relType, fromMajor, fromMinor, toMajor, toMinor)
+}
@@ -0,0 +1,130 @@
+package neo4jrepository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// DefaultMaxConcurrentSessions is the session limit used when Neo4jConfig.MaxConcurrentSessions is zero.
+// A session holds at most one connection at a time, so it matches the default connection pool size.
+const DefaultMaxConcurrentSessions = DefaultMaxConnectionPoolSize
+
+// sessionPool bounds the number of sessions in use and keeps released sessions open for reuse
+type sessionPool struct {
+	// slots holds one token per session in use
+	slots chan struct{}
+	// idle holds open sessions waiting to be reused
+	idle chan neo4j.SessionWithContext
+}
+
+// pooledSession is a session acquired from the pool; Close returns it to the pool instead of closing it
+type pooledSession struct {
+	neo4j.SessionWithContext
+	repo *Neo4jRepository
+	once sync.Once
+
+	// last is the result of the latest auto-commit query, which holds a connection until it is consumed
+	last neo4j.ResultWithContext
+}
+
+// Run runs an auto-commit query and remembers its result so Close can consume it
+func (s *pooledSession) Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error) {
+	result, err := s.SessionWithContext.Run(ctx, cypher, params, configurers...)
+	if err == nil {
+		s.last = result
+	}
+	return result, err
+}
+
+// Close releases the session back to the pool. Only the first call has an effect.
+func (s *pooledSession) Close(ctx context.Context) error {
+	s.once.Do(func() {
+		s.repo.releaseSession(ctx, s.SessionWithContext, s.last)
+	})
+	return nil
+}
+
+// sessions returns the session pool, creating it on first use
+func (r *Neo4jRepository) sessions() *sessionPool {
+	r.sessionPoolOnce.Do(func() {
+		size := DefaultMaxConcurrentSessions
+		if r.config != nil && r.config.MaxConcurrentSessions > 0 {
+			size = r.config.MaxConcurrentSessions
+		}
+		r.sessionPool = &sessionPool{
+			slots: make(chan struct{}, size),
+			idle:  make(chan neo4j.SessionWithContext, size),
+		}
+	})
+	return r.sessionPool
+}
+
+// AcquireSession returns a session from the pool, opening a new one when none is idle.
+// It blocks while Neo4jConfig.MaxConcurrentSessions sessions are in use and fails when ctx is done first.
+// Closing the returned session releases it, as does passing it to ReleaseSession.
+func (r *Neo4jRepository) AcquireSession(ctx context.Context) (neo4j.SessionWithContext, error) {
+	pool := r.sessions()
+	select {
+	case pool.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var session neo4j.SessionWithContext
+	select {
+	case session = <-pool.idle:
+	default:
+		session = r.client.NewSession(ctx, neo4j.SessionConfig{
+			AccessMode: neo4j.AccessModeWrite,
+		})
+	}
+	return &pooledSession{SessionWithContext: session, repo: r}, nil
+}
+
+// ReleaseSession returns a session obtained from AcquireSession to the pool, closing it if the pool is full.
+// Sessions that did not come from the pool are closed.
+func (r *Neo4jRepository) ReleaseSession(ctx context.Context, session neo4j.SessionWithContext) {
+	if session == nil {
+		return
+	}
+	session.Close(ctx)
+}
+
+// releaseSession frees the slot of a pooled session and keeps the session open for reuse when there is room.
+// Any unread records of the last result are discarded first so the idle session does not hold a connection.
+// A session released with a cancelled context or a failed result may be in a broken state, so it is closed instead.
+func (r *Neo4jRepository) releaseSession(ctx context.Context, session neo4j.SessionWithContext, last neo4j.ResultWithContext) {
+	pool := r.sessions()
+	defer func() { <-pool.slots }()
+
+	if ctx.Err() != nil {
+		session.Close(context.Background())
+		return
+	}
+	if last != nil {
+		if _, err := last.Consume(ctx); err != nil {
+			session.Close(ctx)
+			return
+		}
+	}
+	select {
+	case pool.idle <- session:
+	default:
+		session.Close(ctx)
+	}
+}
+
+// closeIdleSessions closes the sessions waiting in the pool
+func (r *Neo4jRepository) closeIdleSessions(ctx context.Context) {
+	pool := r.sessions()
+	for {
+		select {
+		case session := <-pool.idle:
+			session.Close(ctx)
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package neo4jrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"lk/datafoundation/crud-api/db/config"
+	pb "lk/datafoundation/crud-api/lk/datafoundation/crud-api"
+)
+
+// ErrNotImplemented is returned for configuration options the repository does not support yet
+var ErrNotImplemented = errors.New("not implemented")
+
+// tenantPattern restricts tenant identifiers to characters that are safe in a label and keep the prefix unambiguous
+var tenantPattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// labelPattern restricts additional node labels to plain identifiers, so they can be written into Cypher unquoted
+var labelPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateTenantIsolationMode checks that the configured isolation mode is supported
+func validateTenantIsolationMode(mode config.TenantIsolationMode) error {
+	switch mode {
+	case "", config.TenantIsolationLabel:
+		return nil
+	case config.TenantIsolationDatabase:
+		// TODO: route each tenant to its own database through neo4j.SessionConfig.DatabaseName
+		return fmt.Errorf("tenant isolation mode %s: %w", mode, ErrNotImplemented)
+	default:
+		return fmt.Errorf("unknown tenant isolation mode %q", mode)
+	}
+}
+
+// tenantScope is the context value set by WithTenant and WithAllTenants
+type tenantScope struct {
+	tenant string
+	all    bool
+}
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of ctx whose queries only see entities of tenant.
+// Entities are read, changed and linked by Id only within the tenant of the context, and a context without one only
+// sees shared entities. An empty tenant selects the shared entities.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantScope{tenant: tenant})
+}
+
+// WithAllTenants returns a copy of ctx whose queries see the entities of every tenant, for maintenance tools such as the migration
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantScope{all: true})
+}
+
+// TenantFromContext returns the tenant set on ctx by WithTenant, empty for shared entities or when none is set
+func TenantFromContext(ctx context.Context) string {
+	scope, _ := ctx.Value(tenantContextKey{}).(tenantScope)
+	return scope.tenant
+}
+
+// inTenantScope reports whether an entity map returned by ReadGraphEntity is visible to ctx
+func inTenantScope(ctx context.Context, entity map[string]interface{}) bool {
+	scope, _ := ctx.Value(tenantContextKey{}).(tenantScope)
+	tenant, _ := entity["Tenant"].(string)
+	return scope.all || tenant == scope.tenant
+}
+
+// tenantCondition returns a Cypher condition that limits each of nodes to the tenant of ctx and adds the
+// scopeTenant parameter it uses to params. Nodes are matched on their Tenant property, since the tenant label
+// prefix cannot be written into a query that does not know the major kind.
+func tenantCondition(ctx context.Context, params map[string]interface{}, nodes ...string) string {
+	scope, _ := ctx.Value(tenantContextKey{}).(tenantScope)
+	if scope.all {
+		return "true"
+	}
+	params["scopeTenant"] = scope.tenant
+	conditions := make([]string, len(nodes))
+	for i, node := range nodes {
+		conditions[i] = `coalesce(` + node + `.Tenant, '') = $scopeTenant`
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// tenantKindLabel returns the label of kind as kindLabel does, rejecting kinds of a tenant other than the one of ctx
+func tenantKindLabel(ctx context.Context, kind *pb.Kind) (string, error) {
+	scope, _ := ctx.Value(tenantContextKey{}).(tenantScope)
+	if !scope.all && kind.Tenant != scope.tenant {
+		return "", fmt.Errorf("kind tenant %q does not match the tenant %q of the request", kind.Tenant, scope.tenant)
+	}
+	return kindLabel(kind)
+}
+
+// kindLabel returns the node label for a kind, prefixed with its tenant when one is set (e.g. acme_Person)
+func kindLabel(kind *pb.Kind) (string, error) {
+	if kind.Tenant == "" {
+		return kind.Major, nil
+	}
+	if !tenantPattern.MatchString(kind.Tenant) {
+		return "", fmt.Errorf("invalid tenant %q: only letters and digits are allowed", kind.Tenant)
+	}
+	return kind.Tenant + "_" + kind.Major, nil
+}
+
+// additionalLabels returns the extra labels listed in entityMap["Labels"], in order and without repeats of the kind
+// label. Each label must be a plain identifier, and the TerminatedLabel is reserved for the reaper.
+func additionalLabels(entityMap map[string]interface{}, kindLabel string) ([]string, error) {
+	raw, ok := entityMap["Labels"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	labels, ok := raw.([]string)
+	if !ok {
+		return nil, fmt.Errorf("invalid 'Labels' field: expected a list of strings")
+	}
+
+	seen := map[string]bool{kindLabel: true}
+	var extra []string
+	for _, label := range labels {
+		if !labelPattern.MatchString(label) {
+			return nil, fmt.Errorf("invalid label %q: only letters, digits and '_' are allowed, starting with a letter or '_'", label)
+		}
+		if label == TerminatedLabel {
+			return nil, fmt.Errorf("invalid label %q: the label is reserved", label)
+		}
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		extra = append(extra, label)
+	}
+	return extra, nil
+}
+
+// majorKindExpr is a Cypher expression for the major kind of node, with any tenant prefix removed from its label.
+// Nodes created with additional labels store their major kind in the MajorKind property, since labels() does not
+// keep the order labels were added in. The TerminatedLabel added to expired entities is skipped.
+func majorKindExpr(node string) string {
+	label := `[l IN labels(` + node + `) WHERE l <> '` + TerminatedLabel + `'][0]`
+	return `coalesce(` + node + `.MajorKind, CASE WHEN ` + node + `.Tenant IS NULL THEN ` + label + ` ELSE substring(` + label + `, size(` + node + `.Tenant) + 1) END)`
+}
+
+// labelsExpr is a Cypher expression for all labels of node except the TerminatedLabel
+func labelsExpr(node string) string {
+	return `[l IN labels(` + node + `) WHERE l <> '` + TerminatedLabel + `']`
+}
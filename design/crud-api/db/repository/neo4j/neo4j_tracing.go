@@ -0,0 +1,53 @@
+package neo4jrepository
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans created by the repository
+const tracerName = "lk/datafoundation/crud-api/db/repository/neo4j"
+
+// entityIDParams are the query parameters that hold the Id of the entity a query is about, in order of preference
+var entityIDParams = []string{"Id", "entityID", "parentID", "deleteID", "sourceID"}
+
+// startSpan starts a span named neo4j.<method> as a child of ctx for a query run by the given repository method.
+// The tracer is looked up on every call so a provider installed with otel.SetTracerProvider after startup is used.
+func startSpan(ctx context.Context, method string, query string, params map[string]interface{}) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", "neo4j"),
+		attribute.String("query.type", queryType(query)),
+	}
+	for _, key := range entityIDParams {
+		if id, ok := params[key].(string); ok && id != "" {
+			attrs = append(attrs, attribute.String("entity.id", id))
+			break
+		}
+	}
+	return otel.Tracer(tracerName).Start(ctx, "neo4j."+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan sets the span status from the query outcome and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End()
+}
+
+// queryType returns the leading clause of a Cypher query, such as MATCH or MERGE
+func queryType(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -22,10 +22,108 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Direction of a relationship relative to the entity it was read from
+type Direction int32
+
+const (
+	Direction_OUTGOING Direction = 0 // The entity is the source of the relationship
+	Direction_INCOMING Direction = 1 // The entity is the target of the relationship
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "OUTGOING",
+		1: "INCOMING",
+	}
+	Direction_value = map[string]int32{
+		"OUTGOING": 0,
+		"INCOMING": 1,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_v1_proto_enumTypes[0].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_types_v1_proto_enumTypes[0]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{0}
+}
+
+// Strategy used to combine the metadata of two entities
+type MergeStrategy int32
+
+const (
+	MergeStrategy_OVERWRITE MergeStrategy = 0 // Target fields win on conflict
+	MergeStrategy_PRESERVE  MergeStrategy = 1 // Source fields fill target fields that are missing or empty
+	MergeStrategy_UNION     MergeStrategy = 2 // Metadata maps are combined, conflicting keys are an error
+)
+
+// Enum value maps for MergeStrategy.
+var (
+	MergeStrategy_name = map[int32]string{
+		0: "OVERWRITE",
+		1: "PRESERVE",
+		2: "UNION",
+	}
+	MergeStrategy_value = map[string]int32{
+		"OVERWRITE": 0,
+		"PRESERVE":  1,
+		"UNION":     2,
+	}
+)
+
+func (x MergeStrategy) Enum() *MergeStrategy {
+	p := new(MergeStrategy)
+	*p = x
+	return p
+}
+
+func (x MergeStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MergeStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_v1_proto_enumTypes[1].Descriptor()
+}
+
+func (MergeStrategy) Type() protoreflect.EnumType {
+	return &file_types_v1_proto_enumTypes[1]
+}
+
+func (x MergeStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MergeStrategy.Descriptor instead.
+func (MergeStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{1}
+}
+
 type Kind struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Major         string                 `protobuf:"bytes,1,opt,name=major,proto3" json:"major,omitempty"`
 	Minor         string                 `protobuf:"bytes,2,opt,name=minor,proto3" json:"minor,omitempty"`
+	Tenant        string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"` // Optional tenant owning the entity, empty for shared entities
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,6 +172,13 @@ func (x *Kind) GetMinor() string {
 	return ""
 }
 
+func (x *Kind) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
 type TimeBasedValue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StartTime     string                 `protobuf:"bytes,1,opt,name=startTime,proto3" json:"startTime,omitempty"`
@@ -141,6 +246,7 @@ type Relationship struct {
 	EndTime         string                 `protobuf:"bytes,3,opt,name=endTime,proto3" json:"endTime,omitempty"`
 	Id              string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
 	Name            string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Properties      map[string]*anypb.Any  `protobuf:"bytes,6,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Typed properties carried by the relationship
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -210,6 +316,74 @@ func (x *Relationship) GetName() string {
 	return ""
 }
 
+func (x *Relationship) GetProperties() map[string]*anypb.Any {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+// A relationship read from the graph together with its direction
+type RelationshipWithDirection struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Relationship    *Relationship          `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	Direction       Direction              `protobuf:"varint,2,opt,name=direction,proto3,enum=crud.Direction" json:"direction,omitempty"`
+	RelatedEntityId string                 `protobuf:"bytes,3,opt,name=relatedEntityId,proto3" json:"relatedEntityId,omitempty"` // The entity at the other end of the relationship
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RelationshipWithDirection) Reset() {
+	*x = RelationshipWithDirection{}
+	mi := &file_types_v1_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipWithDirection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipWithDirection) ProtoMessage() {}
+
+func (x *RelationshipWithDirection) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipWithDirection.ProtoReflect.Descriptor instead.
+func (*RelationshipWithDirection) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RelationshipWithDirection) GetRelationship() *Relationship {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+func (x *RelationshipWithDirection) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_OUTGOING
+}
+
+func (x *RelationshipWithDirection) GetRelatedEntityId() string {
+	if x != nil {
+		return x.RelatedEntityId
+	}
+	return ""
+}
+
 type Entity struct {
 	state         protoimpl.MessageState         `protogen:"open.v1"`
 	Id            string                         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                 // Read-only unique identifier
@@ -226,7 +400,7 @@ type Entity struct {
 
 func (x *Entity) Reset() {
 	*x = Entity{}
-	mi := &file_types_v1_proto_msgTypes[3]
+	mi := &file_types_v1_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -238,7 +412,7 @@ func (x *Entity) String() string {
 func (*Entity) ProtoMessage() {}
 
 func (x *Entity) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[3]
+	mi := &file_types_v1_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -251,7 +425,7 @@ func (x *Entity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Entity.ProtoReflect.Descriptor instead.
 func (*Entity) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{3}
+	return file_types_v1_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Entity) GetId() string {
@@ -320,7 +494,7 @@ type TimeBasedValueList struct {
 
 func (x *TimeBasedValueList) Reset() {
 	*x = TimeBasedValueList{}
-	mi := &file_types_v1_proto_msgTypes[4]
+	mi := &file_types_v1_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -332,7 +506,7 @@ func (x *TimeBasedValueList) String() string {
 func (*TimeBasedValueList) ProtoMessage() {}
 
 func (x *TimeBasedValueList) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[4]
+	mi := &file_types_v1_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -345,7 +519,7 @@ func (x *TimeBasedValueList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TimeBasedValueList.ProtoReflect.Descriptor instead.
 func (*TimeBasedValueList) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{4}
+	return file_types_v1_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TimeBasedValueList) GetValues() []*TimeBasedValue {
@@ -360,14 +534,15 @@ type ReadEntityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Entity        *Entity                `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
-	Output        []string               `protobuf:"bytes,3,rep,name=output,proto3" json:"output,omitempty"`
+	Output        []string               `protobuf:"bytes,3,rep,name=output,proto3" json:"output,omitempty"`         // Fields to fill: metadata, relationships, attributes, kind, name, created, terminated
+	AsOf          string                 `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"` // Optional timestamp to read the entity state at
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReadEntityRequest) Reset() {
 	*x = ReadEntityRequest{}
-	mi := &file_types_v1_proto_msgTypes[5]
+	mi := &file_types_v1_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -379,7 +554,7 @@ func (x *ReadEntityRequest) String() string {
 func (*ReadEntityRequest) ProtoMessage() {}
 
 func (x *ReadEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[5]
+	mi := &file_types_v1_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -392,7 +567,7 @@ func (x *ReadEntityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadEntityRequest.ProtoReflect.Descriptor instead.
 func (*ReadEntityRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{5}
+	return file_types_v1_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ReadEntityRequest) GetId() string {
@@ -416,7 +591,99 @@ func (x *ReadEntityRequest) GetOutput() []string {
 	return nil
 }
 
-// Request message for deleting an entity by ID
+func (x *ReadEntityRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
+// Request message for filtering entities by kind and optional property values
+type FilterEntitiesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Kind              *Kind                  `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Id                string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Created           string                 `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	Terminated        string                 `protobuf:"bytes,5,opt,name=terminated,proto3" json:"terminated,omitempty"`
+	IncludeTerminated bool                   `protobuf:"varint,6,opt,name=include_terminated,json=includeTerminated,proto3" json:"include_terminated,omitempty"` // Also match entities whose Terminated date has passed
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FilterEntitiesRequest) Reset() {
+	*x = FilterEntitiesRequest{}
+	mi := &file_types_v1_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterEntitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterEntitiesRequest) ProtoMessage() {}
+
+func (x *FilterEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*FilterEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FilterEntitiesRequest) GetKind() *Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *FilterEntitiesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FilterEntitiesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FilterEntitiesRequest) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *FilterEntitiesRequest) GetTerminated() string {
+	if x != nil {
+		return x.Terminated
+	}
+	return ""
+}
+
+func (x *FilterEntitiesRequest) GetIncludeTerminated() bool {
+	if x != nil {
+		return x.IncludeTerminated
+	}
+	return false
+}
+
+// Request message for an entity by ID
 type EntityId struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -426,7 +693,7 @@ type EntityId struct {
 
 func (x *EntityId) Reset() {
 	*x = EntityId{}
-	mi := &file_types_v1_proto_msgTypes[6]
+	mi := &file_types_v1_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -438,7 +705,7 @@ func (x *EntityId) String() string {
 func (*EntityId) ProtoMessage() {}
 
 func (x *EntityId) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[6]
+	mi := &file_types_v1_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +718,7 @@ func (x *EntityId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EntityId.ProtoReflect.Descriptor instead.
 func (*EntityId) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{6}
+	return file_types_v1_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *EntityId) GetId() string {
@@ -461,6 +728,59 @@ func (x *EntityId) GetId() string {
 	return ""
 }
 
+// Request message for deleting an entity by ID
+type DeleteEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	HardDelete    bool                   `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3" json:"hard_delete,omitempty"` // Remove the entity instead of stamping its Terminated date
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEntityRequest) Reset() {
+	*x = DeleteEntityRequest{}
+	mi := &file_types_v1_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEntityRequest) ProtoMessage() {}
+
+func (x *DeleteEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEntityRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEntityRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteEntityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteEntityRequest) GetHardDelete() bool {
+	if x != nil {
+		return x.HardDelete
+	}
+	return false
+}
+
 // Request message for updating an entity
 type UpdateEntityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -472,7 +792,7 @@ type UpdateEntityRequest struct {
 
 func (x *UpdateEntityRequest) Reset() {
 	*x = UpdateEntityRequest{}
-	mi := &file_types_v1_proto_msgTypes[7]
+	mi := &file_types_v1_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -484,7 +804,7 @@ func (x *UpdateEntityRequest) String() string {
 func (*UpdateEntityRequest) ProtoMessage() {}
 
 func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[7]
+	mi := &file_types_v1_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -497,7 +817,7 @@ func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateEntityRequest.ProtoReflect.Descriptor instead.
 func (*UpdateEntityRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{7}
+	return file_types_v1_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateEntityRequest) GetId() string {
@@ -514,6 +834,136 @@ func (x *UpdateEntityRequest) GetEntity() *Entity {
 	return nil
 }
 
+// Request message for merging a source entity into a target entity
+type MergeEntitiesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SourceId        string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId        string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Strategy        MergeStrategy          `protobuf:"varint,3,opt,name=strategy,proto3,enum=crud.MergeStrategy" json:"strategy,omitempty"`
+	TerminateSource bool                   `protobuf:"varint,4,opt,name=terminate_source,json=terminateSource,proto3" json:"terminate_source,omitempty"` // Terminate the source entity once merged
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MergeEntitiesRequest) Reset() {
+	*x = MergeEntitiesRequest{}
+	mi := &file_types_v1_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeEntitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeEntitiesRequest) ProtoMessage() {}
+
+func (x *MergeEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*MergeEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MergeEntitiesRequest) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *MergeEntitiesRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *MergeEntitiesRequest) GetStrategy() MergeStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return MergeStrategy_OVERWRITE
+}
+
+func (x *MergeEntitiesRequest) GetTerminateSource() bool {
+	if x != nil {
+		return x.TerminateSource
+	}
+	return false
+}
+
+// Request message for copying an entity under a new Id
+type DuplicateEntityRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NewId             string                 `protobuf:"bytes,2,opt,name=new_id,json=newId,proto3" json:"new_id,omitempty"`                                      // Id of the copy, a random UUID is generated when empty
+	CopyRelationships bool                   `protobuf:"varint,3,opt,name=copy_relationships,json=copyRelationships,proto3" json:"copy_relationships,omitempty"` // Also copy the outgoing relationships of the source entity
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DuplicateEntityRequest) Reset() {
+	*x = DuplicateEntityRequest{}
+	mi := &file_types_v1_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateEntityRequest) ProtoMessage() {}
+
+func (x *DuplicateEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateEntityRequest.ProtoReflect.Descriptor instead.
+func (*DuplicateEntityRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DuplicateEntityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DuplicateEntityRequest) GetNewId() string {
+	if x != nil {
+		return x.NewId
+	}
+	return ""
+}
+
+func (x *DuplicateEntityRequest) GetCopyRelationships() bool {
+	if x != nil {
+		return x.CopyRelationships
+	}
+	return false
+}
+
 // Empty message response
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -523,7 +973,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_types_v1_proto_msgTypes[8]
+	mi := &file_types_v1_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -535,7 +985,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[8]
+	mi := &file_types_v1_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -548,7 +998,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{8}
+	return file_types_v1_proto_rawDescGZIP(), []int{13}
 }
 
 var File_types_v1_proto protoreflect.FileDescriptor
@@ -557,27 +1007,49 @@ var file_types_v1_proto_rawDesc = string([]byte{
 	0x0a, 0x0e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x5f, 0x76, 0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x12, 0x04, 0x63, 0x72, 0x75, 0x64, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x32, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x6a,
+	0x6f, 0x22, 0x4a, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x6a,
 	0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x12,
 	0x14, 0x0a, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x22, 0x74, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x73,
-	0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12,
-	0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x94, 0x01, 0x0a, 0x0c,
-	0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x28, 0x0a, 0x0f,
-	0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x45, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
-	0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x22, 0xdb, 0x04, 0x0a, 0x06, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a,
+	0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x22, 0x74, 0x0a,
+	0x0e, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x73, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0xad, 0x02, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x68, 0x69, 0x70, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65,
+	0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x0a, 0x70, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68,
+	0x69, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x53,
+	0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xac, 0x01, 0x0a, 0x19, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x68, 0x69, 0x70, 0x57, 0x69, 0x74, 0x68, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x36, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x52,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x0c, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x2d, 0x0a, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x63,
+	0x72, 0x75, 0x64, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x49, 0x64, 0x22, 0xdb, 0x04, 0x0a, 0x06, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a,
 	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1e, 0x0a,
 	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x63, 0x72,
 	0x75, 0x64, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a,
@@ -619,36 +1091,90 @@ var file_types_v1_proto_rawDesc = string([]byte{
 	0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
 	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x54, 0x69,
 	0x6d, 0x65, 0x42, 0x61, 0x73, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x73, 0x22, 0x61, 0x0a, 0x11, 0x52, 0x65, 0x61, 0x64, 0x45, 0x6e, 0x74, 0x69,
+	0x6c, 0x75, 0x65, 0x73, 0x22, 0x76, 0x0a, 0x11, 0x52, 0x65, 0x61, 0x64, 0x45, 0x6e, 0x74, 0x69,
 	0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x06, 0x65, 0x6e, 0x74,
 	0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x72, 0x75, 0x64,
 	0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
 	0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x1a, 0x0a, 0x08, 0x45, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x22, 0x4b, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x06, 0x65, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x72, 0x75,
-	0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
-	0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xd4, 0x01, 0x0a, 0x0b, 0x43, 0x72,
-	0x75, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x0c, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x0c, 0x2e, 0x63, 0x72, 0x75, 0x64,
-	0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x1a, 0x0c, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x45,
-	0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x33, 0x0a, 0x0a, 0x52, 0x65, 0x61, 0x64, 0x45, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x45,
-	0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x63,
-	0x72, 0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x37, 0x0a, 0x0c, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x19, 0x2e, 0x63, 0x72, 0x75,
-	0x64, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x12, 0x2b, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x6e, 0x74,
-	0x69, 0x74, 0x79, 0x12, 0x0e, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x49, 0x64, 0x1a, 0x0b, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x42, 0x1c, 0x5a, 0x1a, 0x6c, 0x6b, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x66, 0x6f, 0x75, 0x6e, 0x64,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x72, 0x75, 0x64, 0x2d, 0x61, 0x70, 0x69, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x61, 0x73, 0x5f, 0x6f, 0x66,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x22, 0xc4, 0x01, 0x0a,
+	0x15, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x4b, 0x69, 0x6e, 0x64,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61,
+	0x74, 0x65, 0x64, 0x22, 0x1a, 0x0a, 0x08, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x49, 0x64, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x46, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x68, 0x61, 0x72,
+	0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x22, 0x4b, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24,
+	0x0a, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x06, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x22, 0xac, 0x01, 0x0a, 0x14, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x75, 0x64,
+	0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x08,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x65, 0x72, 0x6d,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0f, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x22, 0x6e, 0x0a, 0x16, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x15, 0x0a,
+	0x06, 0x6e, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e,
+	0x65, 0x77, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6f, 0x70, 0x79, 0x5f, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x11, 0x63, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68,
+	0x69, 0x70, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x2a, 0x27, 0x0a, 0x09,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x55, 0x54,
+	0x47, 0x4f, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x43, 0x4f, 0x4d,
+	0x49, 0x4e, 0x47, 0x10, 0x01, 0x2a, 0x37, 0x0a, 0x0d, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x56, 0x45, 0x52, 0x57, 0x52,
+	0x49, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x45, 0x53, 0x45, 0x52, 0x56,
+	0x45, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x32, 0x98,
+	0x03, 0x0a, 0x0b, 0x43, 0x72, 0x75, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2a,
+	0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x0c,
+	0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x1a, 0x0c, 0x2e, 0x63,
+	0x72, 0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x33, 0x0a, 0x0a, 0x52, 0x65,
+	0x61, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0c, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x37, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x19, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x63, 0x72, 0x75,
+	0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x36, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x19, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x3d, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x1b, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0c, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x30, 0x01, 0x12,
+	0x39, 0x0a, 0x0d, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x12, 0x1a, 0x2e, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x45, 0x6e, 0x74,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x63,
+	0x72, 0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x0f, 0x44, 0x75,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x2e,
+	0x63, 0x72, 0x75, 0x64, 0x2e, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x63, 0x72,
+	0x75, 0x64, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x42, 0x1c, 0x5a, 0x1a, 0x6c, 0x6b, 0x2f,
+	0x64, 0x61, 0x74, 0x61, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63,
+	0x72, 0x75, 0x64, 0x2d, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -663,48 +1189,69 @@ func file_types_v1_proto_rawDescGZIP() []byte {
 	return file_types_v1_proto_rawDescData
 }
 
-var file_types_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_types_v1_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_types_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_types_v1_proto_goTypes = []any{
-	(*Kind)(nil),                // 0: crud.Kind
-	(*TimeBasedValue)(nil),      // 1: crud.TimeBasedValue
-	(*Relationship)(nil),        // 2: crud.Relationship
-	(*Entity)(nil),              // 3: crud.Entity
-	(*TimeBasedValueList)(nil),  // 4: crud.TimeBasedValueList
-	(*ReadEntityRequest)(nil),   // 5: crud.ReadEntityRequest
-	(*EntityId)(nil),            // 6: crud.EntityId
-	(*UpdateEntityRequest)(nil), // 7: crud.UpdateEntityRequest
-	(*Empty)(nil),               // 8: crud.Empty
-	nil,                         // 9: crud.Entity.MetadataEntry
-	nil,                         // 10: crud.Entity.AttributesEntry
-	nil,                         // 11: crud.Entity.RelationshipsEntry
-	(*anypb.Any)(nil),           // 12: google.protobuf.Any
+	(Direction)(0),                    // 0: crud.Direction
+	(MergeStrategy)(0),                // 1: crud.MergeStrategy
+	(*Kind)(nil),                      // 2: crud.Kind
+	(*TimeBasedValue)(nil),            // 3: crud.TimeBasedValue
+	(*Relationship)(nil),              // 4: crud.Relationship
+	(*RelationshipWithDirection)(nil), // 5: crud.RelationshipWithDirection
+	(*Entity)(nil),                    // 6: crud.Entity
+	(*TimeBasedValueList)(nil),        // 7: crud.TimeBasedValueList
+	(*ReadEntityRequest)(nil),         // 8: crud.ReadEntityRequest
+	(*FilterEntitiesRequest)(nil),     // 9: crud.FilterEntitiesRequest
+	(*EntityId)(nil),                  // 10: crud.EntityId
+	(*DeleteEntityRequest)(nil),       // 11: crud.DeleteEntityRequest
+	(*UpdateEntityRequest)(nil),       // 12: crud.UpdateEntityRequest
+	(*MergeEntitiesRequest)(nil),      // 13: crud.MergeEntitiesRequest
+	(*DuplicateEntityRequest)(nil),    // 14: crud.DuplicateEntityRequest
+	(*Empty)(nil),                     // 15: crud.Empty
+	nil,                               // 16: crud.Relationship.PropertiesEntry
+	nil,                               // 17: crud.Entity.MetadataEntry
+	nil,                               // 18: crud.Entity.AttributesEntry
+	nil,                               // 19: crud.Entity.RelationshipsEntry
+	(*anypb.Any)(nil),                 // 20: google.protobuf.Any
 }
 var file_types_v1_proto_depIdxs = []int32{
-	12, // 0: crud.TimeBasedValue.value:type_name -> google.protobuf.Any
-	0,  // 1: crud.Entity.kind:type_name -> crud.Kind
-	1,  // 2: crud.Entity.name:type_name -> crud.TimeBasedValue
-	9,  // 3: crud.Entity.metadata:type_name -> crud.Entity.MetadataEntry
-	10, // 4: crud.Entity.attributes:type_name -> crud.Entity.AttributesEntry
-	11, // 5: crud.Entity.relationships:type_name -> crud.Entity.RelationshipsEntry
-	1,  // 6: crud.TimeBasedValueList.values:type_name -> crud.TimeBasedValue
-	3,  // 7: crud.ReadEntityRequest.entity:type_name -> crud.Entity
-	3,  // 8: crud.UpdateEntityRequest.entity:type_name -> crud.Entity
-	12, // 9: crud.Entity.MetadataEntry.value:type_name -> google.protobuf.Any
-	4,  // 10: crud.Entity.AttributesEntry.value:type_name -> crud.TimeBasedValueList
-	2,  // 11: crud.Entity.RelationshipsEntry.value:type_name -> crud.Relationship
-	3,  // 12: crud.CrudService.CreateEntity:input_type -> crud.Entity
-	5,  // 13: crud.CrudService.ReadEntity:input_type -> crud.ReadEntityRequest
-	7,  // 14: crud.CrudService.UpdateEntity:input_type -> crud.UpdateEntityRequest
-	6,  // 15: crud.CrudService.DeleteEntity:input_type -> crud.EntityId
-	3,  // 16: crud.CrudService.CreateEntity:output_type -> crud.Entity
-	3,  // 17: crud.CrudService.ReadEntity:output_type -> crud.Entity
-	3,  // 18: crud.CrudService.UpdateEntity:output_type -> crud.Entity
-	8,  // 19: crud.CrudService.DeleteEntity:output_type -> crud.Empty
-	16, // [16:20] is the sub-list for method output_type
-	12, // [12:16] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	20, // 0: crud.TimeBasedValue.value:type_name -> google.protobuf.Any
+	16, // 1: crud.Relationship.properties:type_name -> crud.Relationship.PropertiesEntry
+	4,  // 2: crud.RelationshipWithDirection.relationship:type_name -> crud.Relationship
+	0,  // 3: crud.RelationshipWithDirection.direction:type_name -> crud.Direction
+	2,  // 4: crud.Entity.kind:type_name -> crud.Kind
+	3,  // 5: crud.Entity.name:type_name -> crud.TimeBasedValue
+	17, // 6: crud.Entity.metadata:type_name -> crud.Entity.MetadataEntry
+	18, // 7: crud.Entity.attributes:type_name -> crud.Entity.AttributesEntry
+	19, // 8: crud.Entity.relationships:type_name -> crud.Entity.RelationshipsEntry
+	3,  // 9: crud.TimeBasedValueList.values:type_name -> crud.TimeBasedValue
+	6,  // 10: crud.ReadEntityRequest.entity:type_name -> crud.Entity
+	2,  // 11: crud.FilterEntitiesRequest.kind:type_name -> crud.Kind
+	6,  // 12: crud.UpdateEntityRequest.entity:type_name -> crud.Entity
+	1,  // 13: crud.MergeEntitiesRequest.strategy:type_name -> crud.MergeStrategy
+	20, // 14: crud.Relationship.PropertiesEntry.value:type_name -> google.protobuf.Any
+	20, // 15: crud.Entity.MetadataEntry.value:type_name -> google.protobuf.Any
+	7,  // 16: crud.Entity.AttributesEntry.value:type_name -> crud.TimeBasedValueList
+	4,  // 17: crud.Entity.RelationshipsEntry.value:type_name -> crud.Relationship
+	6,  // 18: crud.CrudService.CreateEntity:input_type -> crud.Entity
+	8,  // 19: crud.CrudService.ReadEntity:input_type -> crud.ReadEntityRequest
+	12, // 20: crud.CrudService.UpdateEntity:input_type -> crud.UpdateEntityRequest
+	11, // 21: crud.CrudService.DeleteEntity:input_type -> crud.DeleteEntityRequest
+	9,  // 22: crud.CrudService.StreamEntities:input_type -> crud.FilterEntitiesRequest
+	13, // 23: crud.CrudService.MergeEntities:input_type -> crud.MergeEntitiesRequest
+	14, // 24: crud.CrudService.DuplicateEntity:input_type -> crud.DuplicateEntityRequest
+	6,  // 25: crud.CrudService.CreateEntity:output_type -> crud.Entity
+	6,  // 26: crud.CrudService.ReadEntity:output_type -> crud.Entity
+	6,  // 27: crud.CrudService.UpdateEntity:output_type -> crud.Entity
+	15, // 28: crud.CrudService.DeleteEntity:output_type -> crud.Empty
+	6,  // 29: crud.CrudService.StreamEntities:output_type -> crud.Entity
+	6,  // 30: crud.CrudService.MergeEntities:output_type -> crud.Entity
+	6,  // 31: crud.CrudService.DuplicateEntity:output_type -> crud.Entity
+	25, // [25:32] is the sub-list for method output_type
+	18, // [18:25] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_types_v1_proto_init() }
@@ -717,13 +1264,14 @@ func file_types_v1_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_types_v1_proto_rawDesc), len(file_types_v1_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      2,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_types_v1_proto_goTypes,
 		DependencyIndexes: file_types_v1_proto_depIdxs,
+		EnumInfos:         file_types_v1_proto_enumTypes,
 		MessageInfos:      file_types_v1_proto_msgTypes,
 	}.Build()
 	File_types_v1_proto = out.File
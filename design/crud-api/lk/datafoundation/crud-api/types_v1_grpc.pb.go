@@ -19,10 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CrudService_CreateEntity_FullMethodName = "/crud.CrudService/CreateEntity"
-	CrudService_ReadEntity_FullMethodName   = "/crud.CrudService/ReadEntity"
-	CrudService_UpdateEntity_FullMethodName = "/crud.CrudService/UpdateEntity"
-	CrudService_DeleteEntity_FullMethodName = "/crud.CrudService/DeleteEntity"
+	CrudService_CreateEntity_FullMethodName    = "/crud.CrudService/CreateEntity"
+	CrudService_ReadEntity_FullMethodName      = "/crud.CrudService/ReadEntity"
+	CrudService_UpdateEntity_FullMethodName    = "/crud.CrudService/UpdateEntity"
+	CrudService_DeleteEntity_FullMethodName    = "/crud.CrudService/DeleteEntity"
+	CrudService_StreamEntities_FullMethodName  = "/crud.CrudService/StreamEntities"
+	CrudService_MergeEntities_FullMethodName   = "/crud.CrudService/MergeEntities"
+	CrudService_DuplicateEntity_FullMethodName = "/crud.CrudService/DuplicateEntity"
 )
 
 // CrudServiceClient is the client API for CrudService service.
@@ -34,7 +37,10 @@ type CrudServiceClient interface {
 	CreateEntity(ctx context.Context, in *Entity, opts ...grpc.CallOption) (*Entity, error)
 	ReadEntity(ctx context.Context, in *ReadEntityRequest, opts ...grpc.CallOption) (*Entity, error)
 	UpdateEntity(ctx context.Context, in *UpdateEntityRequest, opts ...grpc.CallOption) (*Entity, error)
-	DeleteEntity(ctx context.Context, in *EntityId, opts ...grpc.CallOption) (*Empty, error)
+	DeleteEntity(ctx context.Context, in *DeleteEntityRequest, opts ...grpc.CallOption) (*Empty, error)
+	StreamEntities(ctx context.Context, in *FilterEntitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Entity], error)
+	MergeEntities(ctx context.Context, in *MergeEntitiesRequest, opts ...grpc.CallOption) (*Entity, error)
+	DuplicateEntity(ctx context.Context, in *DuplicateEntityRequest, opts ...grpc.CallOption) (*Entity, error)
 }
 
 type crudServiceClient struct {
@@ -75,7 +81,7 @@ func (c *crudServiceClient) UpdateEntity(ctx context.Context, in *UpdateEntityRe
 	return out, nil
 }
 
-func (c *crudServiceClient) DeleteEntity(ctx context.Context, in *EntityId, opts ...grpc.CallOption) (*Empty, error) {
+func (c *crudServiceClient) DeleteEntity(ctx context.Context, in *DeleteEntityRequest, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, CrudService_DeleteEntity_FullMethodName, in, out, cOpts...)
@@ -85,6 +91,45 @@ func (c *crudServiceClient) DeleteEntity(ctx context.Context, in *EntityId, opts
 	return out, nil
 }
 
+func (c *crudServiceClient) StreamEntities(ctx context.Context, in *FilterEntitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Entity], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CrudService_ServiceDesc.Streams[0], CrudService_StreamEntities_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FilterEntitiesRequest, Entity]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CrudService_StreamEntitiesClient = grpc.ServerStreamingClient[Entity]
+
+func (c *crudServiceClient) MergeEntities(ctx context.Context, in *MergeEntitiesRequest, opts ...grpc.CallOption) (*Entity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entity)
+	err := c.cc.Invoke(ctx, CrudService_MergeEntities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crudServiceClient) DuplicateEntity(ctx context.Context, in *DuplicateEntityRequest, opts ...grpc.CallOption) (*Entity, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entity)
+	err := c.cc.Invoke(ctx, CrudService_DuplicateEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CrudServiceServer is the server API for CrudService service.
 // All implementations must embed UnimplementedCrudServiceServer
 // for forward compatibility.
@@ -94,7 +139,10 @@ type CrudServiceServer interface {
 	CreateEntity(context.Context, *Entity) (*Entity, error)
 	ReadEntity(context.Context, *ReadEntityRequest) (*Entity, error)
 	UpdateEntity(context.Context, *UpdateEntityRequest) (*Entity, error)
-	DeleteEntity(context.Context, *EntityId) (*Empty, error)
+	DeleteEntity(context.Context, *DeleteEntityRequest) (*Empty, error)
+	StreamEntities(*FilterEntitiesRequest, grpc.ServerStreamingServer[Entity]) error
+	MergeEntities(context.Context, *MergeEntitiesRequest) (*Entity, error)
+	DuplicateEntity(context.Context, *DuplicateEntityRequest) (*Entity, error)
 	mustEmbedUnimplementedCrudServiceServer()
 }
 
@@ -114,9 +162,18 @@ func (UnimplementedCrudServiceServer) ReadEntity(context.Context, *ReadEntityReq
 func (UnimplementedCrudServiceServer) UpdateEntity(context.Context, *UpdateEntityRequest) (*Entity, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntity not implemented")
 }
-func (UnimplementedCrudServiceServer) DeleteEntity(context.Context, *EntityId) (*Empty, error) {
+func (UnimplementedCrudServiceServer) DeleteEntity(context.Context, *DeleteEntityRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntity not implemented")
 }
+func (UnimplementedCrudServiceServer) StreamEntities(*FilterEntitiesRequest, grpc.ServerStreamingServer[Entity]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEntities not implemented")
+}
+func (UnimplementedCrudServiceServer) MergeEntities(context.Context, *MergeEntitiesRequest) (*Entity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeEntities not implemented")
+}
+func (UnimplementedCrudServiceServer) DuplicateEntity(context.Context, *DuplicateEntityRequest) (*Entity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DuplicateEntity not implemented")
+}
 func (UnimplementedCrudServiceServer) mustEmbedUnimplementedCrudServiceServer() {}
 func (UnimplementedCrudServiceServer) testEmbeddedByValue()                     {}
 
@@ -193,7 +250,7 @@ func _CrudService_UpdateEntity_Handler(srv interface{}, ctx context.Context, dec
 }
 
 func _CrudService_DeleteEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EntityId)
+	in := new(DeleteEntityRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -205,7 +262,54 @@ func _CrudService_DeleteEntity_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: CrudService_DeleteEntity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CrudServiceServer).DeleteEntity(ctx, req.(*EntityId))
+		return srv.(CrudServiceServer).DeleteEntity(ctx, req.(*DeleteEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrudService_StreamEntities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FilterEntitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CrudServiceServer).StreamEntities(m, &grpc.GenericServerStream[FilterEntitiesRequest, Entity]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CrudService_StreamEntitiesServer = grpc.ServerStreamingServer[Entity]
+
+func _CrudService_MergeEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeEntitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrudServiceServer).MergeEntities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrudService_MergeEntities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrudServiceServer).MergeEntities(ctx, req.(*MergeEntitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrudService_DuplicateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DuplicateEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrudServiceServer).DuplicateEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrudService_DuplicateEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrudServiceServer).DuplicateEntity(ctx, req.(*DuplicateEntityRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -233,7 +337,21 @@ var CrudService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEntity",
 			Handler:    _CrudService_DeleteEntity_Handler,
 		},
+		{
+			MethodName: "MergeEntities",
+			Handler:    _CrudService_MergeEntities_Handler,
+		},
+		{
+			MethodName: "DuplicateEntity",
+			Handler:    _CrudService_DuplicateEntity_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEntities",
+			Handler:       _CrudService_StreamEntities_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "types_v1.proto",
 }
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// isRetryable reports whether a call failing with err may succeed if sent again.
+// Only transient failures are retried; errors such as InvalidArgument, NotFound and AlreadyExists are returned as is.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoffDelay returns the jittered wait before the given retry (1 for the first retry). The base delay doubles
+// with every retry up to maxBackoff, and the wait is picked at random between half the base delay and the full delay.
+func backoffDelay(retry int, initialBackoff, maxBackoff time.Duration) time.Duration {
+	delay := initialBackoff
+	for i := 1; i < retry && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// withRetry calls attempt up to maxAttempts times, backing off between retryable failures.
+// It gives up early when ctx is done while waiting.
+func withRetry(ctx context.Context, method string, maxAttempts int, initialBackoff, maxBackoff time.Duration, attempt func() error) error {
+	var err error
+	for i := 1; ; i++ {
+		err = attempt()
+		if err == nil || !isRetryable(err) || i >= maxAttempts {
+			return err
+		}
+
+		delay := backoffDelay(i, initialBackoff, maxBackoff)
+		log.Printf("[client.Retry] %s failed on attempt %d/%d, retrying in %v: %v", method, i, maxAttempts, delay, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// NewRetryInterceptor returns a unary client interceptor that retries calls failing with Unavailable or
+// DeadlineExceeded, making at most maxAttempts attempts with jittered exponential backoff between them.
+func NewRetryInterceptor(maxAttempts int, initialBackoff, maxBackoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return withRetry(ctx, method, maxAttempts, initialBackoff, maxBackoff, func() error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+	}
+}
+
+// NewRetryStreamInterceptor returns a stream client interceptor that retries opening a stream when it fails with
+// Unavailable or DeadlineExceeded. Errors received after the stream is open are not retried, since messages may
+// already have been exchanged.
+func NewRetryStreamInterceptor(maxAttempts int, initialBackoff, maxBackoff time.Duration) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		var stream grpc.ClientStream
+		err := withRetry(ctx, method, maxAttempts, initialBackoff, maxBackoff, func() error {
+			var err error
+			stream, err = streamer(ctx, desc, cc, method, opts...)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return stream, nil
+	}
+}
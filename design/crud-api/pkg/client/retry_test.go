@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// flakyHealthServer fails the first failures calls to Check with the given code and then reports SERVING
+type flakyHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	mu       sync.Mutex
+	calls    int
+	failures int
+	code     codes.Code
+}
+
+func (s *flakyHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, status.Errorf(s.code, "attempt %d failed", s.calls)
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+// newRetryingClient serves the fake over an in-memory listener and dials it through the retry interceptor
+func newRetryingClient(t *testing.T, fake *flakyHealthServer) grpc_health_v1.HealthClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, fake)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(NewRetryInterceptor(3, time.Millisecond, 10*time.Millisecond)),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create gRPC client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+// TestRetryInterceptorSucceedsOnThirdAttempt verifies that Unavailable errors are retried until the call succeeds
+func TestRetryInterceptorSucceedsOnThirdAttempt(t *testing.T) {
+	fake := &flakyHealthServer{failures: 2, code: codes.Unavailable}
+	client := newRetryingClient(t, fake)
+
+	resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err, "Expected the third attempt to succeed")
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus(), "Expected the final response to be returned")
+	assert.Equal(t, 3, fake.calls, "Expected exactly three calls")
+}
+
+// TestRetryInterceptorGivesUp verifies that the last error is returned once maxAttempts is reached
+func TestRetryInterceptorGivesUp(t *testing.T) {
+	fake := &flakyHealthServer{failures: 5, code: codes.DeadlineExceeded}
+	client := newRetryingClient(t, fake)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err), "Expected the last error to be returned")
+	assert.Equal(t, 3, fake.calls, "Expected no more than maxAttempts calls")
+}
+
+// TestRetryInterceptorNonRetryableCodes verifies that client errors are returned without retrying
+func TestRetryInterceptorNonRetryableCodes(t *testing.T) {
+	for _, code := range []codes.Code{codes.InvalidArgument, codes.NotFound, codes.AlreadyExists} {
+		fake := &flakyHealthServer{failures: 1, code: code}
+		client := newRetryingClient(t, fake)
+
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		assert.Equal(t, code, status.Code(err), "Expected the %s error to be returned", code)
+		assert.Equal(t, 1, fake.calls, "Expected %s not to be retried", code)
+	}
+}
+
+// TestRetryStreamInterceptor verifies that opening a stream is retried on Unavailable
+func TestRetryStreamInterceptor(t *testing.T) {
+	interceptor := NewRetryStreamInterceptor(3, time.Millisecond, 10*time.Millisecond)
+
+	calls := 0
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		calls++
+		if calls < 3 {
+			return nil, status.Error(codes.Unavailable, "not ready")
+		}
+		return nil, nil
+	}
+
+	_, err := interceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, "/crud.CrudService/StreamEntities", streamer)
+	assert.NoError(t, err, "Expected the stream to open on the third attempt")
+	assert.Equal(t, 3, calls, "Expected exactly three attempts")
+}
+
+// TestBackoffDelay verifies that the delay grows exponentially, stays within its jitter range and is capped
+func TestBackoffDelay(t *testing.T) {
+	initialBackoff, maxBackoff := 10*time.Millisecond, 50*time.Millisecond
+	for retry, base := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 40 * time.Millisecond, 4: maxBackoff, 10: maxBackoff} {
+		delay := backoffDelay(retry, initialBackoff, maxBackoff)
+		assert.GreaterOrEqual(t, delay, base/2, "Expected retry %d to wait at least half the base delay", retry)
+		assert.LessOrEqual(t, delay, base, "Expected retry %d to wait at most the base delay", retry)
+	}
+}
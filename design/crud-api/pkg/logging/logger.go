@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Log levels, from most to least verbose
+const (
+	LevelDebug = slog.LevelDebug
+	LevelInfo  = slog.LevelInfo
+	LevelWarn  = slog.LevelWarn
+	LevelError = slog.LevelError
+)
+
+// Logger writes leveled, printf-style log lines. Fields added with WithField are attached to every line
+// written by the returned logger, so a handler can tag all of its lines with the entity it works on.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	WithField(key string, value interface{}) Logger
+}
+
+// slogLogger implements Logger on top of log/slog
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// New creates a Logger writing text lines to w, dropping lines below the given level
+func New(w io.Writer, level slog.Level) Logger {
+	return &slogLogger{logger: slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}))}
+}
+
+// Default creates a Logger writing to stderr at the INFO level
+func Default() Logger {
+	return New(os.Stderr, LevelInfo)
+}
+
+// FromEnv creates a Logger writing to stderr at the level named by LOG_LEVEL, INFO when unset or invalid
+func FromEnv() Logger {
+	value := os.Getenv("LOG_LEVEL")
+	if value == "" {
+		return Default()
+	}
+	level, err := ParseLevel(value)
+	logger := New(os.Stderr, level)
+	if err != nil {
+		logger.Warnf("[logging.FromEnv] Ignoring LOG_LEVEL: %v", err)
+	}
+	return logger
+}
+
+// ParseLevel converts DEBUG, INFO, WARN or ERROR (in any case) to a log level.
+// It returns LevelInfo with an error for any other value.
+func ParseLevel(value string) (slog.Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "DEBUG":
+		return LevelDebug, nil
+	case "INFO":
+		return LevelInfo, nil
+	case "WARN", "WARNING":
+		return LevelWarn, nil
+	case "ERROR":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level %q", value)
+	}
+}
+
+func (l *slogLogger) log(level slog.Level, format string, args ...interface{}) {
+	ctx := context.Background()
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	l.logger.Log(ctx, level, fmt.Sprintf(format, args...))
+}
+
+func (l *slogLogger) Debugf(format string, args ...interface{}) {
+	l.log(LevelDebug, format, args...)
+}
+
+func (l *slogLogger) Infof(format string, args ...interface{}) {
+	l.log(LevelInfo, format, args...)
+}
+
+func (l *slogLogger) Warnf(format string, args ...interface{}) {
+	l.log(LevelWarn, format, args...)
+}
+
+func (l *slogLogger) Errorf(format string, args ...interface{}) {
+	l.log(LevelError, format, args...)
+}
+
+func (l *slogLogger) WithField(key string, value interface{}) Logger {
+	return &slogLogger{logger: l.logger.With(key, value)}
+}
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoggerLevels verifies that lines below the configured level are dropped
+func TestLoggerLevels(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := New(&buffer, LevelWarn)
+
+	logger.Debugf("debug %d", 1)
+	logger.Infof("info %d", 2)
+	logger.Warnf("warn %d", 3)
+	logger.Errorf("error %d", 4)
+
+	output := buffer.String()
+	assert.NotContains(t, output, "debug 1")
+	assert.NotContains(t, output, "info 2")
+	assert.Contains(t, output, `level=WARN msg="warn 3"`)
+	assert.Contains(t, output, `level=ERROR msg="error 4"`)
+}
+
+// TestLoggerWithField verifies that fields are attached to every line of the derived logger only
+func TestLoggerWithField(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := New(&buffer, LevelDebug)
+
+	logger.WithField("entity_id", "entity-1").Infof("first")
+	logger.Infof("second")
+
+	lines := bytes.Split(bytes.TrimSpace(buffer.Bytes()), []byte("\n"))
+	assert.Equal(t, 2, len(lines))
+	assert.Contains(t, string(lines[0]), "entity_id=entity-1", "Expected the field on the derived logger's line")
+	assert.NotContains(t, string(lines[1]), "entity_id", "Expected the parent logger to be unchanged")
+}
+
+// TestParseLevel verifies the accepted LOG_LEVEL values
+func TestParseLevel(t *testing.T) {
+	for value, expected := range map[string]interface{}{"debug": LevelDebug, "INFO": LevelInfo, "Warn": LevelWarn, "ERROR": LevelError} {
+		level, err := ParseLevel(value)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level, "Expected %s to parse", value)
+	}
+
+	level, err := ParseLevel("verbose")
+	assert.Error(t, err, "Expected an unknown level to be rejected")
+	assert.Equal(t, LevelInfo, level, "Expected INFO as the fallback level")
+}
@@ -27,7 +27,7 @@ echo "Running go build ./..."
 go build ./... || { echo "Error: Failed to build packages"; exit 1; }
 
 echo "Building crud-service..."
-go build -o crud-service cmd/server/service.go cmd/server/utils.go || { echo "Error: Failed to build crud-service"; exit 1; }
+go build -o crud-service ./cmd/server || { echo "Error: Failed to build crud-service"; exit 1; }
 
 echo "Build completed successfully!"
 
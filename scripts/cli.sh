@@ -28,7 +28,7 @@ build_crud() {
     go build ./... || { echo "Error: Failed to build packages"; exit 1; }
     
     echo "Building crud-service..."
-    go build -o crud-service cmd/server/service.go cmd/server/utils.go || { echo "Error: Failed to build crud-service"; exit 1; }
+    go build -o crud-service ./cmd/server || { echo "Error: Failed to build crud-service"; exit 1; }
     
     echo "Running tests..."
     go test -v ./... || { echo "Error: Failed to test packages"; exit 1; }